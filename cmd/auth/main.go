@@ -10,6 +10,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
 )
 
 const tokenFile = ".whoop_token.json"
@@ -20,6 +22,7 @@ type tokenData struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresIn    int       `json:"expires_in"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	Scope        string    `json:"scope"`
 }
 
 func main() {
@@ -65,20 +68,19 @@ func runAuthFlow(clientID, clientSecret string) {
 		}
 	}
 
-	scopes := []string{
-		"offline",
-		"read:recovery",
-		"read:cycles",
-		"read:workout",
-		"read:sleep",
-		"read:profile",
-		"read:body_measurement",
-	}
+	scopes := append([]whoop.Scope{whoop.ScopeOffline}, whoop.ScopesFor(
+		whoop.ServiceCycle,
+		whoop.ServiceRecovery,
+		whoop.ServiceSleep,
+		whoop.ServiceWorkout,
+		whoop.ServiceProfile,
+		whoop.ServiceBodyMeasurement,
+	)...)
 
 	authURL := fmt.Sprintf("https://api.prod.whoop.com/oauth/oauth2/auth?client_id=%s&response_type=code&redirect_uri=%s&scope=%s&state=whoop-go-state",
 		clientID,
 		url.QueryEscape(redirectURI),
-		url.QueryEscape(strings.Join(scopes, " ")),
+		url.QueryEscape(whoop.JoinScopes(scopes)),
 	)
 
 	fmt.Println("=== WHOOP OAuth 2.0 Token Generator ===")
@@ -226,4 +228,7 @@ func printToken(tok *tokenData) {
 		fmt.Printf("\nRefresh token saved to %s — next time you run this script, it will auto-refresh without a browser login.\n", tokenFile)
 	}
 	fmt.Printf("\nToken expires at %s (in %d seconds).\n", tok.ExpiresAt.Format(time.RFC3339), tok.ExpiresIn)
+	if tok.Scope != "" {
+		fmt.Printf("\nGranted scopes: %s\nPass them to whoop.WithScopes(whoop.ParseScopes(...)...) to catch missing scopes before a request.\n", tok.Scope)
+	}
 }
@@ -123,7 +123,8 @@ func processWorkout(client *whoop.Client, workoutID string) {
 }
 
 // saveWorkoutToLocalFile persists the workout data to a local JSON file.
-// In a production environment, this would likely involve a database like PostgreSQL or MongoDB.
+// In a production environment, store.SQLSink persists records to PostgreSQL
+// instead, with store.ApplyWebhook handling both updates and deletions.
 func saveWorkoutToLocalFile(workout *whoop.Workout) error {
 	data, err := json.MarshalIndent(workout, "", "  ")
 	if err != nil {
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+	"github.com/arvarik/whoop-go/whoop/promexporter"
+)
+
+// This command serves the latest WHOOP metrics of one or more users on
+// /metrics for Prometheus to scrape. Tokens are read from WHOOP_TOKENS as a
+// comma-separated list of user=token pairs, for example
+//
+//	WHOOP_TOKENS="alice=...,bob=..." go run ./cmd/promexporter -addr :9090
+//
+// or from WHOOP_OAUTH_TOKEN for a single user labelled "me".
+func main() {
+	addr := flag.String("addr", ":9090", "address to serve /metrics on")
+	interval := flag.Duration("interval", promexporter.DefaultInterval, "how often to refresh metrics from the WHOOP API")
+	flag.Parse()
+
+	targets, err := parseTargets(os.Getenv("WHOOP_TOKENS"), os.Getenv("WHOOP_OAUTH_TOKEN"))
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	exp := promexporter.New(*interval, targets...)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		_ = exp.Run(ctx)
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exp)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving metrics for %d user(s) on %s/metrics", len(targets), *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// parseTargets builds one target per user=token pair in tokens, or a single
+// target labelled "me" for fallback when tokens is empty.
+func parseTargets(tokens, fallback string) ([]promexporter.Target, error) {
+	if strings.TrimSpace(tokens) == "" {
+		if fallback == "" {
+			return nil, errors.New("WHOOP_TOKENS or WHOOP_OAUTH_TOKEN environment variable is required")
+		}
+		return []promexporter.Target{{User: "me", Client: whoop.NewClient(whoop.WithToken(fallback))}}, nil
+	}
+
+	var targets []promexporter.Target
+	seen := make(map[string]bool)
+	for pair := range strings.SplitSeq(tokens, ",") {
+		user, token, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || user == "" || token == "" {
+			return nil, fmt.Errorf("invalid WHOOP_TOKENS entry %q, want user=token", pair)
+		}
+		if seen[user] {
+			return nil, fmt.Errorf("duplicate user %q in WHOOP_TOKENS", user)
+		}
+		seen[user] = true
+		targets = append(targets, promexporter.Target{User: user, Client: whoop.NewClient(whoop.WithToken(token))})
+	}
+	return targets, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestParseTargets(t *testing.T) {
+	targets, err := parseTargets(" alice=a1 , bob=b2", "ignored")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 2 || targets[0].User != "alice" || targets[1].User != "bob" {
+		t.Errorf("unexpected targets %+v", targets)
+	}
+
+	targets, err = parseTargets("", "tok")
+	if err != nil || len(targets) != 1 || targets[0].User != "me" {
+		t.Errorf("expected a single fallback target, got %+v (%v)", targets, err)
+	}
+
+	for _, tokens := range []string{"alice", "=tok", "alice=", "alice=a,alice=b"} {
+		if _, err := parseTargets(tokens, ""); err == nil {
+			t.Errorf("expected an error for %q", tokens)
+		}
+	}
+	if _, err := parseTargets("", ""); err == nil {
+		t.Error("expected an error without any token")
+	}
+}
@@ -0,0 +1,190 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// Default AnomalyOptions values.
+const (
+	DefaultAnomalyBaselineDays = Month
+	DefaultAnomalyMinSamples   = 7
+	DefaultAnomalyThreshold    = 2.0
+	DefaultAnomalyMinSignals   = 2
+)
+
+// Names of the signals returned by IllnessSignals.
+const (
+	SignalRestingHeartRate = "resting_heart_rate"
+	SignalRespiratoryRate  = "respiratory_rate"
+	SignalHRV              = "hrv"
+	SignalSkinTemp         = "skin_temp"
+)
+
+// Direction is the direction of a deviation from baseline.
+type Direction int
+
+const (
+	// Either matches deviations in both directions.
+	Either Direction = iota
+	// Up matches values above baseline.
+	Up
+	// Down matches values below baseline.
+	Down
+)
+
+// String returns "up", "down" or "either".
+func (d Direction) String() string {
+	switch d {
+	case Up:
+		return "up"
+	case Down:
+		return "down"
+	default:
+		return "either"
+	}
+}
+
+// Signal is a daily series watched for deviations in a given direction.
+type Signal struct {
+	// Name identifies the signal in the resulting explanations.
+	Name string
+
+	Series Series
+
+	// Direction selects which deviations count toward an anomaly.
+	Direction Direction
+
+	// Threshold overrides AnomalyOptions.Threshold for this signal when positive.
+	Threshold float64
+}
+
+// AnomalyOptions configures DetectAnomalies. Zero values select the defaults.
+type AnomalyOptions struct {
+	// BaselineDays is the length of the trailing baseline window in calendar days.
+	BaselineDays int
+
+	// MinSamples is the number of baseline samples a signal needs before its
+	// deviations are considered.
+	MinSamples int
+
+	// Threshold is the absolute z-score a signal must reach to count as deviating.
+	Threshold float64
+
+	// MinSignals is the number of signals that must deviate on the same day for
+	// the day to be flagged.
+	MinSignals int
+}
+
+func (o AnomalyOptions) withDefaults() AnomalyOptions {
+	if o.BaselineDays <= 0 {
+		o.BaselineDays = DefaultAnomalyBaselineDays
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = DefaultAnomalyMinSamples
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = DefaultAnomalyThreshold
+	}
+	if o.MinSignals <= 0 {
+		o.MinSignals = DefaultAnomalyMinSignals
+	}
+	return o
+}
+
+// SignalDeviation explains how one signal deviated on an anomalous day.
+type SignalDeviation struct {
+	Signal string
+
+	// Direction is the direction the value moved relative to its baseline.
+	Direction Direction
+
+	Deviation
+}
+
+// String describes the deviation, for example
+// "resting_heart_rate up: 61.0 vs baseline 54.2 ± 2.1 (z=+3.24)".
+func (d SignalDeviation) String() string {
+	return fmt.Sprintf("%s %s: %.1f vs baseline %.1f ± %.1f (z=%+.2f)",
+		d.Signal, d.Direction, d.Value, d.Baseline.Mean, d.Baseline.StdDev, d.ZScore)
+}
+
+// Anomaly is a day on which at least MinSignals signals deviated together.
+type Anomaly struct {
+	Date whoop.Date
+
+	// Deviations lists the deviating signals in the order they were passed to
+	// DetectAnomalies.
+	Deviations []SignalDeviation
+
+	// Evaluated is the number of signals that had a valid baseline on Date.
+	Evaluated int
+}
+
+// DetectAnomalies compares each signal against its personal rolling baseline
+// and returns, in date order, the days on which at least opts.MinSignals
+// signals deviated beyond their threshold in their watched direction.
+func DetectAnomalies(signals []Signal, opts AnomalyOptions) []Anomaly {
+	opts = opts.withDefaults()
+
+	deviations := make([]map[whoop.Date]Deviation, len(signals))
+	var dates []whoop.Date
+	for i, sig := range signals {
+		deviations[i] = make(map[whoop.Date]Deviation, len(sig.Series))
+		for _, d := range ZScores(sig.Series, opts.BaselineDays, opts.MinSamples) {
+			if d.Valid {
+				deviations[i][d.Date] = d
+				dates = append(dates, d.Date)
+			}
+		}
+	}
+	slices.SortFunc(dates, whoop.Date.Compare)
+	dates = slices.Compact(dates)
+
+	var out []Anomaly
+	for _, date := range dates {
+		a := Anomaly{Date: date}
+		for i, sig := range signals {
+			d, ok := deviations[i][date]
+			if !ok {
+				continue
+			}
+			a.Evaluated++
+
+			threshold := opts.Threshold
+			if sig.Threshold > 0 {
+				threshold = sig.Threshold
+			}
+			if math.Abs(d.ZScore) < threshold {
+				continue
+			}
+			dir := Up
+			if d.ZScore < 0 {
+				dir = Down
+			}
+			if sig.Direction != Either && sig.Direction != dir {
+				continue
+			}
+			a.Deviations = append(a.Deviations, SignalDeviation{Signal: sig.Name, Direction: dir, Deviation: d})
+		}
+		if len(a.Deviations) >= opts.MinSignals {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// IllnessSignals returns the signals commonly associated with illness and
+// overreaching: resting heart rate and respiratory rate rising, HRV dropping,
+// and skin temperature deviating in either direction.
+func IllnessSignals(recoveries []whoop.Recovery, sleeps []whoop.Sleep, opts RecoveryOptions) []Signal {
+	return []Signal{
+		{Name: SignalRestingHeartRate, Series: RecoverySeries(recoveries, sleeps, RestingHeartRate, opts), Direction: Up},
+		{Name: SignalRespiratoryRate, Series: SleepSeries(sleeps, RespiratoryRate), Direction: Up},
+		{Name: SignalHRV, Series: RecoverySeries(recoveries, sleeps, HRV, opts), Direction: Down},
+		{Name: SignalSkinTemp, Series: RecoverySeries(recoveries, sleeps, SkinTemp, opts), Direction: Either},
+	}
+}
@@ -0,0 +1,111 @@
+package analytics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// illnessHistory builds 14 nights of alternating baseline values followed by
+// a final night described by last.
+func illnessHistory(last whoop.RecoveryScore, lastRespiratoryRate float64) ([]whoop.Recovery, []whoop.Sleep) {
+	var recoveries []whoop.Recovery
+	var sleeps []whoop.Sleep
+	for i := range 15 {
+		id := fmt.Sprintf("s%d", i)
+		wobble := float64(i%2*2 - 1) // -1, +1, -1, ...
+		rec := whoop.RecoveryScore{
+			RestingHeartRate: 50 + wobble,
+			HrvRmssdMilli:    80 + 2*wobble,
+			SkinTempCelsius:  33.5 + 0.1*wobble,
+		}
+		rr := 15 + 0.2*wobble
+		if i == 14 {
+			rec, rr = last, lastRespiratoryRate
+		}
+		sleeps = append(sleeps, sleepEnding(id, day0.AddDays(i), &whoop.SleepScore{RespiratoryRate: rr}))
+		recoveries = append(recoveries, whoop.Recovery{SleepID: id, ScoreState: whoop.ScoreStateScored, Score: &rec})
+	}
+	return recoveries, sleeps
+}
+
+func TestDetectAnomalies_IllnessSignals(t *testing.T) {
+	recoveries, sleeps := illnessHistory(whoop.RecoveryScore{
+		RestingHeartRate: 58,   // well above baseline
+		HrvRmssdMilli:    60,   // well below baseline
+		SkinTempCelsius:  33.5, // unchanged
+	}, 17)
+
+	anomalies := DetectAnomalies(IllnessSignals(recoveries, sleeps, RecoveryOptions{}), AnomalyOptions{})
+	if len(anomalies) != 1 {
+		t.Fatalf("expected one anomalous day, got %+v", anomalies)
+	}
+
+	a := anomalies[0]
+	if a.Date != day0.AddDays(14) || a.Evaluated != 4 {
+		t.Errorf("unexpected anomaly %s with %d signals evaluated", a.Date, a.Evaluated)
+	}
+	var names []string
+	for _, d := range a.Deviations {
+		names = append(names, d.Signal)
+	}
+	want := []string{SignalRestingHeartRate, SignalRespiratoryRate, SignalHRV}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected deviations %v, got %v", want, names)
+	}
+
+	rhr := a.Deviations[0]
+	if rhr.Direction != Up || rhr.Value != 58 || rhr.ZScore < 2 {
+		t.Errorf("unexpected resting heart rate deviation: %+v", rhr)
+	}
+	if hrv := a.Deviations[2]; hrv.Direction != Down || hrv.ZScore > -2 {
+		t.Errorf("unexpected HRV deviation: %+v", hrv)
+	}
+	if s := rhr.String(); !strings.HasPrefix(s, "resting_heart_rate up: 58.0 vs baseline 50.0") {
+		t.Errorf("unexpected explanation %q", s)
+	}
+}
+
+func TestDetectAnomalies_DirectionAndMinSignals(t *testing.T) {
+	// A recovery that improved in every metric is not an illness signal.
+	recoveries, sleeps := illnessHistory(whoop.RecoveryScore{
+		RestingHeartRate: 42,
+		HrvRmssdMilli:    110,
+		SkinTempCelsius:  33.5,
+	}, 15)
+	signals := IllnessSignals(recoveries, sleeps, RecoveryOptions{})
+	if got := DetectAnomalies(signals, AnomalyOptions{MinSignals: 1}); len(got) != 0 {
+		t.Errorf("expected improvements to be ignored, got %+v", got)
+	}
+
+	// A lone skin temperature swing only counts when a single signal suffices.
+	recoveries, sleeps = illnessHistory(whoop.RecoveryScore{
+		RestingHeartRate: 50,
+		HrvRmssdMilli:    80,
+		SkinTempCelsius:  32.5,
+	}, 15)
+	signals = IllnessSignals(recoveries, sleeps, RecoveryOptions{})
+	if got := DetectAnomalies(signals, AnomalyOptions{}); len(got) != 0 {
+		t.Errorf("expected a single deviation not to be flagged, got %+v", got)
+	}
+	got := DetectAnomalies(signals, AnomalyOptions{MinSignals: 1})
+	if len(got) != 1 || got[0].Deviations[0].Signal != SignalSkinTemp || got[0].Deviations[0].Direction != Down {
+		t.Errorf("expected a downward skin temperature deviation, got %+v", got)
+	}
+
+	// A per-signal threshold can make a signal less sensitive.
+	signals[3].Threshold = 100
+	if got := DetectAnomalies(signals, AnomalyOptions{MinSignals: 1}); len(got) != 0 {
+		t.Errorf("expected per-signal threshold to suppress the deviation, got %+v", got)
+	}
+}
+
+func TestDetectAnomalies_InsufficientBaseline(t *testing.T) {
+	recoveries, sleeps := illnessHistory(whoop.RecoveryScore{RestingHeartRate: 70, HrvRmssdMilli: 20}, 25)
+	signals := IllnessSignals(recoveries, sleeps, RecoveryOptions{})
+	if got := DetectAnomalies(signals, AnomalyOptions{MinSamples: 30}); len(got) != 0 {
+		t.Errorf("expected no anomalies without enough history, got %+v", got)
+	}
+}
@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"math"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// Common rolling window lengths, in calendar days.
+const (
+	Week    = 7
+	Month   = 30
+	Quarter = 90
+)
+
+// Stats summarizes a set of values.
+type Stats struct {
+	N      int
+	Mean   float64
+	StdDev float64 // Sample standard deviation; zero when N < 2.
+}
+
+// Describe computes summary statistics for values.
+func Describe(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	st := Stats{N: len(values), Mean: sum / float64(len(values))}
+
+	if st.N > 1 {
+		var sq float64
+		for _, v := range values {
+			sq += (v - st.Mean) * (v - st.Mean)
+		}
+		st.StdDev = math.Sqrt(sq / float64(st.N-1))
+	}
+	return st
+}
+
+// describeSamples computes summary statistics for the values of samples.
+func describeSamples(samples []Sample) Stats {
+	return Describe(Series(samples).Values())
+}
+
+// RollingPoint holds the statistics of a rolling window ending on Date.
+type RollingPoint struct {
+	Date whoop.Date
+	Stats
+}
+
+// Rolling computes, for every sample in s, the statistics of the samples
+// within the trailing window of days calendar days ending on and including
+// the sample's date. Because windows span calendar days, gaps in the series
+// reduce N instead of stretching the window.
+func Rolling(s Series, days int) []RollingPoint {
+	days = max(days, 1)
+	out := make([]RollingPoint, len(s))
+	lo := 0
+	for i, sample := range s {
+		for sample.Date.DaysSince(s[lo].Date) >= days {
+			lo++
+		}
+		out[i] = RollingPoint{Date: sample.Date, Stats: describeSamples(s[lo : i+1])}
+	}
+	return out
+}
+
+// Deviation compares one sample against its personal baseline.
+type Deviation struct {
+	Date  whoop.Date
+	Value float64
+
+	// Baseline summarizes the samples in the days calendar days before Date,
+	// excluding Date itself.
+	Baseline Stats
+
+	// ZScore is (Value - Baseline.Mean) / Baseline.StdDev. It is only
+	// meaningful when Valid is true.
+	ZScore float64
+
+	// Valid reports whether the baseline had at least the minimum number of
+	// samples and a non-zero standard deviation.
+	Valid bool
+}
+
+// ZScores compares every sample in s against the baseline formed by the
+// samples in the preceding days calendar days. Baselines with fewer than
+// minSamples samples, such as during the first days of data or after long
+// gaps, produce deviations with Valid set to false. The last element is the
+// latest day compared against its baseline.
+func ZScores(s Series, days, minSamples int) []Deviation {
+	out := make([]Deviation, len(s))
+	lo := 0
+	for i, sample := range s {
+		for lo < i && sample.Date.DaysSince(s[lo].Date) > days {
+			lo++
+		}
+		out[i] = deviation(sample, describeSamples(s[lo:i]), minSamples)
+	}
+	return out
+}
+
+// deviation scores sample against baseline, marking it valid only when the
+// baseline has at least minSamples samples and non-zero spread.
+func deviation(sample Sample, baseline Stats, minSamples int) Deviation {
+	d := Deviation{Date: sample.Date, Value: sample.Value, Baseline: baseline}
+	if baseline.N >= minSamples && baseline.N > 1 && baseline.StdDev > 0 {
+		d.ZScore = (sample.Value - baseline.Mean) / baseline.StdDev
+		d.Valid = true
+	}
+	return d
+}
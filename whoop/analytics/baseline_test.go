@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	st := Describe([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if st.N != 8 || st.Mean != 5 {
+		t.Errorf("unexpected stats: %+v", st)
+	}
+	if math.Abs(st.StdDev-2.138089935) > 1e-9 {
+		t.Errorf("expected sample stddev ~2.138, got %f", st.StdDev)
+	}
+
+	if (Describe(nil) != Stats{}) {
+		t.Error("expected zero stats for no values")
+	}
+	if st := Describe([]float64{3}); st.N != 1 || st.Mean != 3 || st.StdDev != 0 {
+		t.Errorf("unexpected single-value stats: %+v", st)
+	}
+}
+
+func TestRolling_CalendarWindowWithGaps(t *testing.T) {
+	s := Series{
+		{Date: day0, Value: 10},
+		{Date: day0.AddDays(1), Value: 20},
+		{Date: day0.AddDays(5), Value: 30}, // gap of three days
+		{Date: day0.AddDays(6), Value: 40},
+	}
+
+	points := Rolling(s, 3)
+	wantN := []int{1, 2, 1, 2}
+	wantMean := []float64{10, 15, 30, 35}
+	for i, p := range points {
+		if p.N != wantN[i] || p.Mean != wantMean[i] {
+			t.Errorf("point %d: got N=%d mean=%v, want N=%d mean=%v", i, p.N, p.Mean, wantN[i], wantMean[i])
+		}
+	}
+}
+
+func TestZScores(t *testing.T) {
+	var s Series
+	for i, v := range []float64{60, 62, 58, 61, 59, 60, 40} {
+		s = append(s, Sample{Date: day0.AddDays(i), Value: v})
+	}
+
+	devs := ZScores(s, Week, 5)
+	if len(devs) != len(s) {
+		t.Fatalf("expected %d deviations, got %d", len(s), len(devs))
+	}
+
+	for i := 0; i < 5; i++ {
+		if devs[i].Valid {
+			t.Errorf("expected deviation %d to be invalid with only %d baseline samples", i, devs[i].Baseline.N)
+		}
+	}
+
+	today := devs[len(devs)-1]
+	if !today.Valid || today.Baseline.N != 6 || today.Baseline.Mean != 60 {
+		t.Fatalf("unexpected baseline for today: %+v", today)
+	}
+	if today.ZScore > -10 {
+		t.Errorf("expected a large negative z-score for the HRV drop, got %f", today.ZScore)
+	}
+}
+
+func TestZScores_WindowExcludesOldSamples(t *testing.T) {
+	s := Series{
+		{Date: day0, Value: 100},
+		{Date: day0.AddDays(10), Value: 1},
+		{Date: day0.AddDays(11), Value: 2},
+		{Date: day0.AddDays(12), Value: 3},
+	}
+
+	last := ZScores(s, Week, 2)[3]
+	if last.Baseline.N != 2 || last.Baseline.Mean != 1.5 {
+		t.Errorf("expected baseline of the two preceding days, got %+v", last.Baseline)
+	}
+}
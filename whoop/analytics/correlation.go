@@ -0,0 +1,253 @@
+package analytics
+
+import (
+	"math"
+	"slices"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// z95 is the standard normal quantile for a two-sided 95% confidence interval.
+const z95 = 1.959964
+
+// DayMetric extracts a value from a joined day, reporting false when the value
+// is unavailable, for example because the linked record is missing or unscored.
+type DayMetric func(*whoop.Day) (float64, bool)
+
+// DayRecovery adapts a RecoveryMetric to the day's scored recovery.
+func DayRecovery(metric RecoveryMetric) DayMetric {
+	return func(d *whoop.Day) (float64, bool) {
+		if d.Recovery == nil || !d.Recovery.IsScored() {
+			return 0, false
+		}
+		return metric(d.Recovery.Score), true
+	}
+}
+
+// DaySleep adapts a SleepMetric to the day's scored main sleep.
+func DaySleep(metric SleepMetric) DayMetric {
+	return func(d *whoop.Day) (float64, bool) {
+		if d.Sleep == nil || !d.Sleep.IsScored() {
+			return 0, false
+		}
+		return metric(d.Sleep.Score), true
+	}
+}
+
+// DayCycle adapts a CycleMetric to the day's scored cycle.
+func DayCycle(metric CycleMetric) DayMetric {
+	return func(d *whoop.Day) (float64, bool) {
+		if !d.Cycle.IsScored() {
+			return 0, false
+		}
+		return metric(d.Cycle.Score), true
+	}
+}
+
+// Pair is one aligned observation of two metrics.
+type Pair struct {
+	// Date is the local day of the Y observation. X was observed lag days earlier.
+	Date whoop.Date
+	X    float64
+	Y    float64
+}
+
+// AlignDays pairs y on each day with x on the day lag days earlier, matching
+// days by the local date of their cycle. A lag of 1 pairs, for example, one
+// day's strain with the following day's recovery. Negative lags pair x with
+// later days. Days missing either value are skipped. When several cycles
+// start on the same local date, as can happen around time zone changes, only
+// the earliest is used, for both x and y.
+func AlignDays(days []whoop.Day, x, y DayMetric, lag int) []Pair {
+	byDate := make(map[whoop.Date]*whoop.Day, len(days))
+	for i := range days {
+		d := &days[i]
+		date, err := d.LocalDate()
+		if err != nil {
+			continue
+		}
+		if prev, ok := byDate[date]; !ok || d.Cycle.Start.Before(prev.Cycle.Start) {
+			byDate[date] = d
+		}
+	}
+
+	pairs := make([]Pair, 0, len(byDate))
+	for date, d := range byDate {
+		yv, ok := y(d)
+		if !ok {
+			continue
+		}
+		xd, ok := byDate[date.AddDays(-lag)]
+		if !ok {
+			continue
+		}
+		xv, ok := x(xd)
+		if !ok {
+			continue
+		}
+		pairs = append(pairs, Pair{Date: date, X: xv, Y: yv})
+	}
+	slices.SortFunc(pairs, func(a, b Pair) int { return a.Date.Compare(b.Date) })
+	return pairs
+}
+
+// Correlation is a correlation coefficient with its sample size and an
+// approximate 95% confidence interval from the Fisher z-transformation.
+type Correlation struct {
+	N int
+	R float64
+
+	// Lower and Upper bound the 95% confidence interval of R. With fewer than
+	// four pairs the interval is the uninformative [-1, 1].
+	Lower float64
+	Upper float64
+
+	// Valid reports whether R is defined: there were at least two pairs and
+	// neither variable was constant.
+	Valid bool
+}
+
+// Pearson computes the Pearson product-moment correlation of the pairs.
+func Pearson(pairs []Pair) Correlation {
+	xs, ys := make([]float64, len(pairs)), make([]float64, len(pairs))
+	for i, p := range pairs {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	return correlation(xs, ys)
+}
+
+// Spearman computes the Spearman rank correlation of the pairs. Tied values
+// receive the average of the ranks they span. The confidence interval uses the
+// same Fisher approximation as Pearson.
+func Spearman(pairs []Pair) Correlation {
+	xs, ys := make([]float64, len(pairs)), make([]float64, len(pairs))
+	for i, p := range pairs {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	return correlation(ranks(xs), ranks(ys))
+}
+
+// CorrelationReport holds both correlation coefficients of aligned metrics.
+type CorrelationReport struct {
+	Pairs    []Pair
+	Pearson  Correlation
+	Spearman Correlation
+}
+
+// Correlate aligns x and y across days with AlignDays and computes their
+// Pearson and Spearman correlations.
+func Correlate(days []whoop.Day, x, y DayMetric, lag int) CorrelationReport {
+	pairs := AlignDays(days, x, y, lag)
+	return CorrelationReport{Pairs: pairs, Pearson: Pearson(pairs), Spearman: Spearman(pairs)}
+}
+
+func correlation(xs, ys []float64) Correlation {
+	c := Correlation{N: len(xs), Lower: -1, Upper: 1}
+	if c.N < 2 {
+		return c
+	}
+
+	mx, my := Describe(xs).Mean, Describe(ys).Mean
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return c
+	}
+	c.R = max(-1, min(1, sxy/math.Sqrt(sxx*syy)))
+	c.Valid = true
+
+	if c.N > 3 {
+		z := math.Atanh(c.R)
+		se := 1 / math.Sqrt(float64(c.N-3))
+		c.Lower = math.Tanh(z - z95*se)
+		c.Upper = math.Tanh(z + z95*se)
+	}
+	return c
+}
+
+// ranks returns the 1-based rank of each value, averaging the ranks of ties.
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case values[a] < values[b]:
+			return -1
+		case values[a] > values[b]:
+			return 1
+		}
+		return 0
+	})
+
+	out := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		avg := float64(i+j+1) / 2 // mean of ranks i+1 through j
+		for _, idx := range order[i:j] {
+			out[idx] = avg
+		}
+		i = j
+	}
+	return out
+}
+
+// CohortComparison compares a metric between two groups of days.
+type CohortComparison struct {
+	// In summarizes the days matching the cohort predicate, and Out the rest.
+	In  Stats
+	Out Stats
+
+	// Difference is In.Mean minus Out.Mean.
+	Difference float64
+
+	// CohensD is Difference divided by the pooled standard deviation. It is
+	// only meaningful when Valid is true.
+	CohensD float64
+
+	// Valid reports whether both groups had at least two values and the pooled
+	// standard deviation was non-zero.
+	Valid bool
+}
+
+// CompareCohorts splits days by inCohort and compares metric between the two
+// groups, for example recovery on days after drinking alcohol versus other
+// days. Days for which metric is unavailable are skipped.
+func CompareCohorts(days []whoop.Day, metric DayMetric, inCohort func(*whoop.Day) bool) CohortComparison {
+	var in, out []float64
+	for i := range days {
+		d := &days[i]
+		v, ok := metric(d)
+		if !ok {
+			continue
+		}
+		if inCohort(d) {
+			in = append(in, v)
+		} else {
+			out = append(out, v)
+		}
+	}
+
+	c := CohortComparison{In: Describe(in), Out: Describe(out)}
+	c.Difference = c.In.Mean - c.Out.Mean
+	if c.In.N < 2 || c.Out.N < 2 {
+		return c
+	}
+
+	pooledVar := (float64(c.In.N-1)*c.In.StdDev*c.In.StdDev + float64(c.Out.N-1)*c.Out.StdDev*c.Out.StdDev) /
+		float64(c.In.N+c.Out.N-2)
+	if pooledVar > 0 {
+		c.CohensD = c.Difference / math.Sqrt(pooledVar)
+		c.Valid = true
+	}
+	return c
+}
@@ -0,0 +1,165 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// joinedDay builds a day whose cycle starts at 07:00 local time (-08:00) on
+// date with the given strain and a scored recovery with the given score. A
+// negative recovery leaves the day without a recovery.
+func joinedDay(date whoop.Date, strain, recovery float64) whoop.Day {
+	d := whoop.Day{Cycle: whoop.Cycle{
+		Start:          date.In(time.UTC).Add(15 * time.Hour),
+		TimezoneOffset: "-08:00",
+		ScoreState:     whoop.ScoreStateScored,
+		Score:          &whoop.Score{Strain: strain},
+	}}
+	if recovery >= 0 {
+		d.Recovery = &whoop.Recovery{ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{RecoveryScore: recovery}}
+	}
+	return d
+}
+
+func TestAlignDays_Lag(t *testing.T) {
+	days := []whoop.Day{
+		joinedDay(day0, 10, 60),
+		joinedDay(day0.AddDays(1), 15, 50),
+		joinedDay(day0.AddDays(2), 8, -1),  // no recovery
+		joinedDay(day0.AddDays(4), 12, 70), // the day before is missing
+	}
+	strain, recovery := DayCycle(Strain), DayRecovery(RecoveryScore)
+
+	same := AlignDays(days, strain, recovery, 0)
+	if len(same) != 3 {
+		t.Fatalf("expected 3 same-day pairs, got %+v", same)
+	}
+
+	next := AlignDays(days, strain, recovery, 1)
+	if len(next) != 1 {
+		t.Fatalf("expected 1 lagged pair, got %+v", next)
+	}
+	if next[0].Date != day0.AddDays(1) || next[0].X != 10 || next[0].Y != 50 {
+		t.Errorf("expected day0 strain paired with next recovery, got %+v", next[0])
+	}
+}
+
+func TestAlignDays_DuplicateDate(t *testing.T) {
+	// A second, later cycle starting on day0's local date, as after a time
+	// zone change, must not replace the first or add a second pair.
+	extra := joinedDay(day0, 99, 1)
+	extra.Cycle.Start = extra.Cycle.Start.Add(6 * time.Hour)
+	days := []whoop.Day{
+		extra,
+		joinedDay(day0, 10, 60),
+		joinedDay(day0.AddDays(1), 15, 50),
+	}
+	strain, recovery := DayCycle(Strain), DayRecovery(RecoveryScore)
+
+	same := AlignDays(days, strain, recovery, 0)
+	if len(same) != 2 || same[0].X != 10 || same[0].Y != 60 {
+		t.Errorf("expected the earliest cycle on day0 to be used, got %+v", same)
+	}
+	next := AlignDays(days, strain, recovery, 1)
+	if len(next) != 1 || next[0].X != 10 || next[0].Y != 50 {
+		t.Errorf("expected the earliest cycle's strain to be lagged, got %+v", next)
+	}
+}
+
+func TestPearson(t *testing.T) {
+	var pairs []Pair
+	for i := range 10 {
+		x := float64(i)
+		pairs = append(pairs, Pair{X: x, Y: 3*x + 2})
+	}
+	c := Pearson(pairs)
+	if !c.Valid || c.N != 10 || math.Abs(c.R-1) > 1e-12 {
+		t.Errorf("expected perfect correlation, got %+v", c)
+	}
+
+	// Neighbouring values swapped pairwise: r = 29/35, with a finite interval.
+	pairs = []Pair{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 4}, {X: 4, Y: 3}, {X: 5, Y: 6}, {X: 6, Y: 5}}
+	c = Pearson(pairs)
+	if math.Abs(c.R-0.8285714) > 1e-6 {
+		t.Errorf("expected r of 0.8286, got %f", c.R)
+	}
+	if !(c.Lower < c.R && c.R < c.Upper) || c.Lower < -1 || c.Upper > 1 {
+		t.Errorf("expected confidence interval around r, got [%f, %f]", c.Lower, c.Upper)
+	}
+	// Fisher interval: tanh(atanh(r) ± 1.96/sqrt(3)).
+	if want := math.Tanh(math.Atanh(c.R) - z95/math.Sqrt(3)); math.Abs(c.Lower-want) > 1e-12 {
+		t.Errorf("expected lower bound %f, got %f", want, c.Lower)
+	}
+
+	if c := Pearson([]Pair{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 3}}); c.Valid || c.Lower != -1 || c.Upper != 1 {
+		t.Errorf("expected constant x to be invalid, got %+v", c)
+	}
+}
+
+func TestSpearman_Ties(t *testing.T) {
+	got := ranks([]float64{10, 20, 20, 5, 30})
+	want := []float64{2, 3.5, 3.5, 1, 5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected ranks %v, got %v", want, got)
+		}
+	}
+
+	// A monotonic but non-linear relationship is a perfect rank correlation.
+	var pairs []Pair
+	for i := 1; i <= 8; i++ {
+		pairs = append(pairs, Pair{X: float64(i), Y: math.Exp(float64(i))})
+	}
+	if c := Spearman(pairs); math.Abs(c.R-1) > 1e-12 {
+		t.Errorf("expected rank correlation of 1, got %+v", c)
+	}
+	if c := Pearson(pairs); c.R >= 0.99 {
+		t.Errorf("expected linear correlation below 1, got %f", c.R)
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	var days []whoop.Day
+	for i := range 10 {
+		// Recovery falls the day after high strain.
+		strain := float64(5 + i%3*5)
+		days = append(days, joinedDay(day0.AddDays(i), strain, 0))
+	}
+	for i := 1; i < len(days); i++ {
+		days[i].Recovery.Score.RecoveryScore = 100 - 4*days[i-1].Cycle.Score.Strain
+	}
+
+	report := Correlate(days, DayCycle(Strain), DayRecovery(RecoveryScore), 1)
+	if len(report.Pairs) != 9 || report.Pearson.N != 9 {
+		t.Fatalf("expected 9 pairs, got %d", len(report.Pairs))
+	}
+	if math.Abs(report.Pearson.R+1) > 1e-12 || math.Abs(report.Spearman.R+1) > 1e-12 {
+		t.Errorf("expected perfect negative correlation, got %+v / %+v", report.Pearson, report.Spearman)
+	}
+}
+
+func TestCompareCohorts(t *testing.T) {
+	days := []whoop.Day{
+		joinedDay(day0, 18, 40),
+		joinedDay(day0.AddDays(1), 16, 50),
+		joinedDay(day0.AddDays(2), 6, 70),
+		joinedDay(day0.AddDays(3), 4, 80),
+		joinedDay(day0.AddDays(4), 5, -1),
+	}
+	hard := func(d *whoop.Day) bool { return d.Cycle.Score.Strain >= 14 }
+
+	c := CompareCohorts(days, DayRecovery(RecoveryScore), hard)
+	if c.In.N != 2 || c.Out.N != 2 || c.In.Mean != 45 || c.Out.Mean != 75 {
+		t.Fatalf("unexpected cohorts: %+v", c)
+	}
+	if c.Difference != -30 {
+		t.Errorf("expected difference of -30, got %f", c.Difference)
+	}
+	// Both groups have a standard deviation of sqrt(50).
+	if want := -30 / math.Sqrt(50); !c.Valid || math.Abs(c.CohensD-want) > 1e-12 {
+		t.Errorf("expected Cohen's d %f, got %+v", want, c)
+	}
+}
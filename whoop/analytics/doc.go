@@ -0,0 +1,29 @@
+// Package analytics computes trends and derived statistics from WHOOP records.
+//
+// The functions in this package are pure: they operate on slices of the model
+// types returned by the whoop client and never perform requests. Records are
+// assigned to calendar days in the user's local time using each record's own
+// TimezoneOffset, and unscored records are skipped, which leaves gaps rather
+// than zeros in the resulting series.
+//
+// # Rolling Baselines
+//
+//	hrv := analytics.RecoverySeries(recoveries, sleeps, analytics.HRV, analytics.RecoveryOptions{
+//	    ExcludeCalibrating: true,
+//	})
+//	for _, d := range analytics.ZScores(hrv, analytics.Month, 7) {
+//	    fmt.Printf("%s: %.1f ms (z=%.2f)\n", d.Date, d.Value, d.ZScore)
+//	}
+//
+// # Anomalies
+//
+// DetectAnomalies flags days on which several signals deviate from their
+// baselines together, such as the illness signals returned by IllnessSignals:
+//
+//	signals := analytics.IllnessSignals(recoveries, sleeps, analytics.RecoveryOptions{})
+//	for _, a := range analytics.DetectAnomalies(signals, analytics.AnomalyOptions{}) {
+//	    for _, d := range a.Deviations {
+//	        fmt.Printf("%s: %s\n", a.Date, d)
+//	    }
+//	}
+package analytics
@@ -0,0 +1,182 @@
+package analytics
+
+import (
+	"slices"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// Sample is a single daily observation of a metric.
+type Sample struct {
+	Date  whoop.Date
+	Value float64
+}
+
+// Series is a daily time series ordered by date with at most one sample per
+// date. Days without data are absent rather than zero.
+type Series []Sample
+
+// NewSeries sorts samples by date and merges samples that share a date into
+// their mean, returning a well-formed Series.
+func NewSeries(samples []Sample) Series {
+	sorted := slices.Clone(samples)
+	slices.SortStableFunc(sorted, func(a, b Sample) int { return a.Date.Compare(b.Date) })
+
+	out := make(Series, 0, len(sorted))
+	for i := 0; i < len(sorted); {
+		j, sum := i, 0.0
+		for ; j < len(sorted) && sorted[j].Date == sorted[i].Date; j++ {
+			sum += sorted[j].Value
+		}
+		out = append(out, Sample{Date: sorted[i].Date, Value: sum / float64(j-i)})
+		i = j
+	}
+	return out
+}
+
+// Values returns the sample values in date order.
+func (s Series) Values() []float64 {
+	out := make([]float64, len(s))
+	for i, sample := range s {
+		out[i] = sample.Value
+	}
+	return out
+}
+
+// Lookup returns the value recorded on date, if any.
+func (s Series) Lookup(date whoop.Date) (float64, bool) {
+	i, ok := slices.BinarySearchFunc(s, date, func(sample Sample, d whoop.Date) int { return sample.Date.Compare(d) })
+	if !ok {
+		return 0, false
+	}
+	return s[i].Value, true
+}
+
+// Between returns the samples dated from from to to, inclusive.
+func (s Series) Between(from, to whoop.Date) Series {
+	lo, _ := slices.BinarySearchFunc(s, from, func(sample Sample, d whoop.Date) int { return sample.Date.Compare(d) })
+	hi, found := slices.BinarySearchFunc(s, to, func(sample Sample, d whoop.Date) int { return sample.Date.Compare(d) })
+	if found {
+		hi++
+	}
+	if lo >= hi {
+		return nil
+	}
+	return s[lo:hi]
+}
+
+// RecoveryMetric extracts a value from a scored recovery.
+type RecoveryMetric func(*whoop.RecoveryScore) float64
+
+// SleepMetric extracts a value from a scored sleep.
+type SleepMetric func(*whoop.SleepScore) float64
+
+// CycleMetric extracts a value from a scored cycle.
+type CycleMetric func(*whoop.Score) float64
+
+// HRV extracts heart rate variability (RMSSD) in milliseconds.
+func HRV(s *whoop.RecoveryScore) float64 { return s.HrvRmssdMilli }
+
+// RestingHeartRate extracts the resting heart rate in beats per minute.
+func RestingHeartRate(s *whoop.RecoveryScore) float64 { return s.RestingHeartRate }
+
+// RecoveryScore extracts the recovery score percentage.
+func RecoveryScore(s *whoop.RecoveryScore) float64 { return s.RecoveryScore }
+
+// SkinTemp extracts the skin temperature in degrees Celsius.
+func SkinTemp(s *whoop.RecoveryScore) float64 { return s.SkinTempCelsius }
+
+// SpO2 extracts the blood oxygen saturation percentage.
+func SpO2(s *whoop.RecoveryScore) float64 { return s.Spo2Percentage }
+
+// RespiratoryRate extracts the respiratory rate in breaths per minute.
+func RespiratoryRate(s *whoop.SleepScore) float64 { return s.RespiratoryRate }
+
+// SleepPerformance extracts the sleep performance percentage.
+func SleepPerformance(s *whoop.SleepScore) float64 { return s.SleepPerformancePercentage }
+
+// SleepEfficiency extracts the sleep efficiency percentage.
+func SleepEfficiency(s *whoop.SleepScore) float64 { return s.SleepEfficiencyPercentage }
+
+// Strain extracts the cycle strain.
+func Strain(s *whoop.Score) float64 { return s.Strain }
+
+// Kilojoules extracts the energy expended during the cycle in kilojoules.
+func Kilojoules(s *whoop.Score) float64 { return s.Kilojoule }
+
+// RecoveryOptions controls how recoveries are turned into a Series.
+type RecoveryOptions struct {
+	// ExcludeCalibrating drops recoveries recorded while WHOOP was still
+	// calibrating to the user, which are less reliable for baselines.
+	ExcludeCalibrating bool
+}
+
+// RecoverySeries builds a daily series from scored recoveries. Recoveries do
+// not carry a timestamp or timezone of their own, so each one is dated by the
+// local wake-up day of the sleep referenced by its SleepID; recoveries whose
+// sleep is not in sleeps are skipped.
+func RecoverySeries(recoveries []whoop.Recovery, sleeps []whoop.Sleep, metric RecoveryMetric, opts RecoveryOptions) Series {
+	dates := sleepDates(sleeps)
+
+	samples := make([]Sample, 0, len(recoveries))
+	for i := range recoveries {
+		r := &recoveries[i]
+		if !r.IsScored() || (opts.ExcludeCalibrating && r.Score.UserCalibrating) {
+			continue
+		}
+		date, ok := dates[r.SleepID]
+		if !ok {
+			continue
+		}
+		samples = append(samples, Sample{Date: date, Value: metric(r.Score)})
+	}
+	return NewSeries(samples)
+}
+
+// SleepSeries builds a daily series from scored main sleeps, dated by the
+// local wake-up day. Naps are excluded.
+func SleepSeries(sleeps []whoop.Sleep, metric SleepMetric) Series {
+	samples := make([]Sample, 0, len(sleeps))
+	for i := range sleeps {
+		s := &sleeps[i]
+		if s.Nap || !s.IsScored() {
+			continue
+		}
+		date, err := s.LocalDate()
+		if err != nil {
+			continue
+		}
+		samples = append(samples, Sample{Date: date, Value: metric(s.Score)})
+	}
+	return NewSeries(samples)
+}
+
+// CycleSeries builds a daily series from scored cycles, dated by the local day
+// the cycle started.
+func CycleSeries(cycles []whoop.Cycle, metric CycleMetric) Series {
+	samples := make([]Sample, 0, len(cycles))
+	for i := range cycles {
+		c := &cycles[i]
+		if !c.IsScored() {
+			continue
+		}
+		date, err := c.LocalDate()
+		if err != nil {
+			continue
+		}
+		samples = append(samples, Sample{Date: date, Value: metric(c.Score)})
+	}
+	return NewSeries(samples)
+}
+
+// sleepDates maps sleep IDs to their local wake-up day, skipping sleeps with
+// an invalid timezone offset.
+func sleepDates(sleeps []whoop.Sleep) map[string]whoop.Date {
+	dates := make(map[string]whoop.Date, len(sleeps))
+	for i := range sleeps {
+		if date, err := sleeps[i].LocalDate(); err == nil {
+			dates[sleeps[i].ID] = date
+		}
+	}
+	return dates
+}
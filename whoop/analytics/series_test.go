@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+var day0 = whoop.Date{Year: 2026, Month: time.February, Day: 1}
+
+// sleepEnding builds a scored main sleep waking up at 07:00 local time on date.
+func sleepEnding(id string, date whoop.Date, score *whoop.SleepScore) whoop.Sleep {
+	end := date.In(time.UTC).Add(15 * time.Hour) // 07:00 at -08:00
+	s := whoop.Sleep{
+		ID:             id,
+		Start:          end.Add(-8 * time.Hour),
+		End:            end,
+		TimezoneOffset: "-08:00",
+		ScoreState:     whoop.ScoreStateScored,
+		Score:          score,
+	}
+	if score == nil {
+		s.ScoreState = whoop.ScoreStatePendingScore
+	}
+	return s
+}
+
+func TestNewSeries_SortsAndMerges(t *testing.T) {
+	s := NewSeries([]Sample{
+		{Date: day0.AddDays(2), Value: 3},
+		{Date: day0, Value: 1},
+		{Date: day0.AddDays(2), Value: 5},
+	})
+
+	if len(s) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(s))
+	}
+	if s[0].Date != day0 || s[1].Value != 4 {
+		t.Errorf("unexpected series: %+v", s)
+	}
+	if v, ok := s.Lookup(day0.AddDays(2)); !ok || v != 4 {
+		t.Errorf("expected lookup of merged value 4, got %v (%v)", v, ok)
+	}
+	if _, ok := s.Lookup(day0.AddDays(1)); ok {
+		t.Error("expected gap day to be missing")
+	}
+	if got := s.Between(day0.AddDays(1), day0.AddDays(5)); len(got) != 1 || got[0].Value != 4 {
+		t.Errorf("unexpected Between result: %+v", got)
+	}
+	if got := s.Between(day0, day0.AddDays(2)); len(got) != 2 {
+		t.Errorf("expected inclusive Between, got %+v", got)
+	}
+}
+
+func TestRecoverySeries(t *testing.T) {
+	sleeps := []whoop.Sleep{
+		sleepEnding("s1", day0, &whoop.SleepScore{}),
+		sleepEnding("s2", day0.AddDays(1), &whoop.SleepScore{}),
+		sleepEnding("s3", day0.AddDays(2), &whoop.SleepScore{}),
+	}
+	recoveries := []whoop.Recovery{
+		{SleepID: "s1", ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{HrvRmssdMilli: 50, UserCalibrating: true}},
+		{SleepID: "s2", ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{HrvRmssdMilli: 60}},
+		{SleepID: "s3", ScoreState: whoop.ScoreStatePendingScore},
+		{SleepID: "unknown", ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{HrvRmssdMilli: 70}},
+	}
+
+	all := RecoverySeries(recoveries, sleeps, HRV, RecoveryOptions{})
+	if len(all) != 2 || all[0].Date != day0 || all[1].Value != 60 {
+		t.Errorf("unexpected series: %+v", all)
+	}
+
+	calibrated := RecoverySeries(recoveries, sleeps, HRV, RecoveryOptions{ExcludeCalibrating: true})
+	if len(calibrated) != 1 || calibrated[0].Date != day0.AddDays(1) {
+		t.Errorf("expected calibrating recovery to be excluded, got %+v", calibrated)
+	}
+}
+
+func TestSleepAndCycleSeries(t *testing.T) {
+	nap := sleepEnding("nap", day0, &whoop.SleepScore{RespiratoryRate: 20})
+	nap.Nap = true
+	sleeps := []whoop.Sleep{
+		sleepEnding("s1", day0, &whoop.SleepScore{RespiratoryRate: 15}),
+		nap,
+		sleepEnding("s2", day0.AddDays(1), nil),
+	}
+
+	rr := SleepSeries(sleeps, RespiratoryRate)
+	if len(rr) != 1 || rr[0].Value != 15 {
+		t.Errorf("expected only the scored main sleep, got %+v", rr)
+	}
+
+	cycles := []whoop.Cycle{
+		{Start: day0.In(time.UTC).Add(15 * time.Hour), TimezoneOffset: "-08:00", ScoreState: whoop.ScoreStateScored, Score: &whoop.Score{Strain: 10}},
+		{Start: day0.In(time.UTC).Add(39 * time.Hour), TimezoneOffset: "invalid", ScoreState: whoop.ScoreStateScored, Score: &whoop.Score{Strain: 12}},
+		{Start: day0.In(time.UTC).Add(63 * time.Hour), TimezoneOffset: "-08:00", ScoreState: whoop.ScoreStateUnscorable},
+	}
+	strain := CycleSeries(cycles, Strain)
+	if len(strain) != 1 || strain[0].Date != day0 || strain[0].Value != 10 {
+		t.Errorf("unexpected strain series: %+v", strain)
+	}
+}
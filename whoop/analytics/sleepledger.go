@@ -0,0 +1,208 @@
+package analytics
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// SleepNight reconciles one night's sleep need with the sleep actually obtained.
+type SleepNight struct {
+	// Date is the local day the user woke up.
+	Date whoop.Date
+
+	// SleepID is the ID of the night's main sleep.
+	SleepID string
+
+	// Need is WHOOP's total sleep need for the night, including debt, strain
+	// and nap adjustments. Baseline is the need excluding those adjustments.
+	Need     time.Duration
+	Baseline time.Duration
+
+	// Asleep is the time spent asleep during the main sleep, and Naps is the
+	// time asleep during naps taken since the previous main sleep ended.
+	// Actual is their sum.
+	Asleep time.Duration
+	Naps   time.Duration
+	Actual time.Duration
+
+	// Balance is Actual minus Need; negative values mean the user fell short.
+	Balance time.Duration
+
+	// CumulativeDebt is the running shortfall against Baseline. Nights with
+	// more sleep than the baseline repay debt, but it never drops below zero.
+	CumulativeDebt time.Duration
+
+	// Bedtime and WakeTime are the start and end of the main sleep in the
+	// user's local time.
+	Bedtime  time.Time
+	WakeTime time.Time
+}
+
+// SleepWeek summarizes the nights of one local week.
+type SleepWeek struct {
+	// Start is the first day of the week.
+	Start whoop.Date
+
+	Nights        int
+	AverageNeed   time.Duration
+	AverageActual time.Duration
+
+	// TotalBalance is the sum of the week's nightly balances.
+	TotalBalance time.Duration
+
+	// EndingDebt is the cumulative debt after the week's last night.
+	EndingDebt time.Duration
+
+	// AverageBedtime and AverageWakeTime are local clock times expressed as
+	// offsets from midnight. Bedtimes before and after midnight are averaged
+	// together correctly, so 23:30 and 00:30 average to 00:00.
+	AverageBedtime  time.Duration
+	AverageWakeTime time.Duration
+
+	// BedtimeStdDev and WakeTimeStdDev measure the consistency of the week's
+	// bed and wake times; lower is more consistent.
+	BedtimeStdDev  time.Duration
+	WakeTimeStdDev time.Duration
+}
+
+// SleepLedger is the night-by-night and weekly sleep need accounting for a
+// series of sleeps.
+type SleepLedger struct {
+	Nights []SleepNight
+	Weeks  []SleepWeek
+}
+
+// LedgerOptions configures NewSleepLedger.
+type LedgerOptions struct {
+	// WeekStart is the first day of each reporting week. The zero value is Sunday.
+	WeekStart time.Weekday
+}
+
+// NewSleepLedger walks sleeps and reconciles each scored main sleep's need
+// with the sleep obtained. Naps count toward the next main sleep that starts
+// after them, matching how WHOOP reduces the following night's need. Naps
+// after the last main sleep are not yet attributed to a night. Unscored
+// sleeps and sleeps with an invalid timezone offset are skipped.
+func NewSleepLedger(sleeps []whoop.Sleep, opts LedgerOptions) *SleepLedger {
+	var mains, naps []*whoop.Sleep
+	for i := range sleeps {
+		s := &sleeps[i]
+		if !s.IsScored() || s.Score.StageSummary == nil {
+			continue
+		}
+		if _, err := s.Location(); err != nil {
+			continue
+		}
+		if s.Nap {
+			naps = append(naps, s)
+		} else if s.Score.SleepNeeded != nil {
+			mains = append(mains, s)
+		}
+	}
+
+	slices.SortFunc(mains, func(a, b *whoop.Sleep) int { return a.Start.Compare(b.Start) })
+
+	napTime := make([]time.Duration, len(mains))
+	for _, nap := range naps {
+		i, _ := slices.BinarySearchFunc(mains, nap.End, func(s *whoop.Sleep, t time.Time) int { return s.Start.Compare(t) })
+		if i < len(mains) {
+			napTime[i] += nap.Score.StageSummary.TotalSleepTime()
+		}
+	}
+
+	ledger := &SleepLedger{Nights: make([]SleepNight, 0, len(mains))}
+	var debt time.Duration
+	for i, s := range mains {
+		// Location was validated above, so these cannot fail.
+		date, _ := s.LocalDate()
+		bed, _ := s.LocalStart()
+		wake, _ := s.LocalEnd()
+
+		night := SleepNight{
+			Date:     date,
+			SleepID:  s.ID,
+			Need:     s.Score.SleepNeeded.Total(),
+			Baseline: s.Score.SleepNeeded.Baseline(),
+			Asleep:   s.Score.StageSummary.TotalSleepTime(),
+			Naps:     napTime[i],
+			Bedtime:  bed,
+			WakeTime: wake,
+		}
+		night.Actual = night.Asleep + night.Naps
+		night.Balance = night.Actual - night.Need
+
+		debt = max(0, debt+night.Baseline-night.Actual)
+		night.CumulativeDebt = debt
+
+		ledger.Nights = append(ledger.Nights, night)
+	}
+
+	ledger.Weeks = summarizeWeeks(ledger.Nights, opts.WeekStart)
+	return ledger
+}
+
+// WeekOf returns the first day of the week containing date.
+func WeekOf(date whoop.Date, weekStart time.Weekday) whoop.Date {
+	offset := (int(date.Weekday()) - int(weekStart) + 7) % 7
+	return date.AddDays(-offset)
+}
+
+// summarizeWeeks groups consecutive nights by week. nights must be in date order.
+func summarizeWeeks(nights []SleepNight, weekStart time.Weekday) []SleepWeek {
+	var weeks []SleepWeek
+	for lo := 0; lo < len(nights); {
+		start := WeekOf(nights[lo].Date, weekStart)
+		hi := lo
+		for hi < len(nights) && WeekOf(nights[hi].Date, weekStart) == start {
+			hi++
+		}
+		weeks = append(weeks, summarizeWeek(start, nights[lo:hi]))
+		lo = hi
+	}
+	return weeks
+}
+
+func summarizeWeek(start whoop.Date, nights []SleepNight) SleepWeek {
+	w := SleepWeek{Start: start, Nights: len(nights), EndingDebt: nights[len(nights)-1].CumulativeDebt}
+
+	var need, actual time.Duration
+	bedtimes := make([]float64, len(nights))
+	wakes := make([]float64, len(nights))
+	for i, n := range nights {
+		need += n.Need
+		actual += n.Actual
+		w.TotalBalance += n.Balance
+		bedtimes[i] = clockHours(n.Bedtime, 12)
+		wakes[i] = clockHours(n.WakeTime, 0)
+	}
+	w.AverageNeed = need / time.Duration(len(nights))
+	w.AverageActual = actual / time.Duration(len(nights))
+
+	bed := Describe(bedtimes)
+	wake := Describe(wakes)
+	w.AverageBedtime = hoursToClock(bed.Mean + 12)
+	w.AverageWakeTime = hoursToClock(wake.Mean)
+	w.BedtimeStdDev = hoursToDuration(bed.StdDev)
+	w.WakeTimeStdDev = hoursToDuration(wake.StdDev)
+	return w
+}
+
+// clockHours returns the local clock time of t in hours since pivotHour, in
+// the range [0, 24). Measuring bedtimes from noon keeps times on either side
+// of midnight adjacent, so they average and spread correctly.
+func clockHours(t time.Time, pivotHour int) float64 {
+	h := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	return math.Mod(h-float64(pivotHour)+24, 24)
+}
+
+// hoursToClock converts hours to a time-of-day offset from midnight in [0, 24h).
+func hoursToClock(h float64) time.Duration {
+	return hoursToDuration(math.Mod(h, 24))
+}
+
+func hoursToDuration(h float64) time.Duration {
+	return time.Duration(math.Round(h * float64(time.Hour)))
+}
@@ -0,0 +1,122 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// ledgerSleep builds a scored sleep starting at startHour local time (-08:00)
+// on date, lasting asleep plus one hour awake, with a 7h30m baseline need.
+func ledgerSleep(id string, date whoop.Date, startHour float64, asleep time.Duration, nap bool) whoop.Sleep {
+	start := date.In(time.UTC).Add(8*time.Hour + time.Duration(startHour*float64(time.Hour)))
+	return whoop.Sleep{
+		ID:             id,
+		Start:          start,
+		End:            start.Add(asleep + time.Hour),
+		TimezoneOffset: "-08:00",
+		Nap:            nap,
+		ScoreState:     whoop.ScoreStateScored,
+		Score: &whoop.SleepScore{
+			StageSummary: &whoop.StageSummary{
+				TotalInBedTimeMilli:      int((asleep + time.Hour).Milliseconds()),
+				TotalAwakeTimeMilli:      int(time.Hour.Milliseconds()),
+				TotalLightSleepTimeMilli: int(asleep.Milliseconds()),
+			},
+			SleepNeeded: &whoop.SleepNeeded{
+				BaselineMilli:          int((7*time.Hour + 30*time.Minute).Milliseconds()),
+				NeedFromSleepDebtMilli: int((30 * time.Minute).Milliseconds()),
+			},
+		},
+	}
+}
+
+func TestNewSleepLedger(t *testing.T) {
+	sleeps := []whoop.Sleep{
+		// Out of order on purpose: the ledger sorts by start time.
+		ledgerSleep("n2", day0.AddDays(1), 0.5, 8*time.Hour, false),   // 00:30 on day0+1
+		ledgerSleep("n1", day0.AddDays(-1), 23.5, 6*time.Hour, false), // 23:30 on day0-1
+		ledgerSleep("nap", day0, 14, 30*time.Minute, true),            // afternoon nap on day0
+		ledgerSleep("n3", day0.AddDays(1), 23, 9*time.Hour, false),    // 23:00 on day0+1
+		sleepEnding("pending", day0.AddDays(4), nil),
+	}
+
+	ledger := NewSleepLedger(sleeps, LedgerOptions{})
+	if len(ledger.Nights) != 3 {
+		t.Fatalf("expected 3 nights, got %d", len(ledger.Nights))
+	}
+
+	n1, n2, n3 := ledger.Nights[0], ledger.Nights[1], ledger.Nights[2]
+	if n1.SleepID != "n1" || n1.Date != day0 {
+		t.Errorf("expected n1 waking on %s, got %s on %s", day0, n1.SleepID, n1.Date)
+	}
+	if n1.Need != 8*time.Hour || n1.Baseline != 7*time.Hour+30*time.Minute {
+		t.Errorf("unexpected need %v / baseline %v", n1.Need, n1.Baseline)
+	}
+	if n1.Actual != 6*time.Hour || n1.Balance != -2*time.Hour {
+		t.Errorf("unexpected actual %v / balance %v", n1.Actual, n1.Balance)
+	}
+	if n1.CumulativeDebt != 90*time.Minute {
+		t.Errorf("expected 1h30m debt after n1, got %v", n1.CumulativeDebt)
+	}
+	if n1.Bedtime.Hour() != 23 || n1.Bedtime.Minute() != 30 {
+		t.Errorf("expected local bedtime 23:30, got %v", n1.Bedtime)
+	}
+
+	// The nap on day0 counts toward the following night.
+	if n2.Naps != 30*time.Minute || n2.Actual != 8*time.Hour+30*time.Minute {
+		t.Errorf("expected nap to be attributed to n2, got naps %v actual %v", n2.Naps, n2.Actual)
+	}
+	if n2.CumulativeDebt != 30*time.Minute {
+		t.Errorf("expected debt to be partially repaid to 30m, got %v", n2.CumulativeDebt)
+	}
+	if n3.CumulativeDebt != 0 {
+		t.Errorf("expected debt to floor at zero, got %v", n3.CumulativeDebt)
+	}
+
+	// day0-1 is a Saturday, so with Sunday weeks n1 falls in the week of day0
+	// (Sunday) along with n2; n3 wakes on day0+2, still in the same week.
+	if len(ledger.Weeks) != 1 {
+		t.Fatalf("expected 1 week, got %+v", ledger.Weeks)
+	}
+	w := ledger.Weeks[0]
+	if w.Start != day0 || w.Nights != 3 || w.EndingDebt != 0 {
+		t.Errorf("unexpected week summary: %+v", w)
+	}
+	// Bedtimes 23:30, 00:30 and 23:00 average to 23:40.
+	if w.AverageBedtime != 23*time.Hour+40*time.Minute {
+		t.Errorf("expected average bedtime 23:40, got %v", w.AverageBedtime)
+	}
+	if w.BedtimeStdDev <= 0 || w.BedtimeStdDev > time.Hour {
+		t.Errorf("expected bedtime spread under an hour, got %v", w.BedtimeStdDev)
+	}
+}
+
+func TestNewSleepLedger_WeekStart(t *testing.T) {
+	sleeps := []whoop.Sleep{
+		ledgerSleep("sun", day0.AddDays(-1), 23, 7*time.Hour, false), // wakes Sunday
+		ledgerSleep("mon", day0, 23, 7*time.Hour, false),             // wakes Monday
+	}
+
+	ledger := NewSleepLedger(sleeps, LedgerOptions{WeekStart: time.Monday})
+	if len(ledger.Weeks) != 2 {
+		t.Fatalf("expected Monday weeks to split Sunday and Monday, got %+v", ledger.Weeks)
+	}
+	if ledger.Weeks[1].Start != day0.AddDays(1) || ledger.Weeks[1].Start.Weekday() != time.Monday {
+		t.Errorf("unexpected second week start %s", ledger.Weeks[1].Start)
+	}
+}
+
+func TestWeekOf(t *testing.T) {
+	wednesday := day0.AddDays(3)
+	if got := WeekOf(wednesday, time.Sunday); got != day0 {
+		t.Errorf("expected %s, got %s", day0, got)
+	}
+	if got := WeekOf(wednesday, time.Monday); got != day0.AddDays(1) {
+		t.Errorf("expected %s, got %s", day0.AddDays(1), got)
+	}
+	if got := WeekOf(day0, time.Sunday); got != day0 {
+		t.Errorf("expected a week start to map to itself, got %s", got)
+	}
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import "github.com/arvarik/whoop-go/whoop"
+
+// Default TrainingLoadOptions values.
+const (
+	DefaultAcuteDays   = 7
+	DefaultChronicDays = 28
+	DefaultSpikeRatio  = 1.5
+)
+
+// TrainingLoadOptions configures TrainingLoad. Zero values select the defaults.
+type TrainingLoadOptions struct {
+	// AcuteDays is the span of the short-term exponentially weighted average.
+	AcuteDays int
+
+	// ChronicDays is the span of the long-term exponentially weighted average.
+	ChronicDays int
+
+	// SpikeRatio is the acute:chronic ratio at or above which a day is flagged
+	// as a load spike.
+	SpikeRatio float64
+}
+
+func (o TrainingLoadOptions) withDefaults() TrainingLoadOptions {
+	if o.AcuteDays <= 0 {
+		o.AcuteDays = DefaultAcuteDays
+	}
+	if o.ChronicDays <= 0 {
+		o.ChronicDays = DefaultChronicDays
+	}
+	if o.SpikeRatio <= 0 {
+		o.SpikeRatio = DefaultSpikeRatio
+	}
+	return o
+}
+
+// LoadPoint is the training load state at the end of one day.
+type LoadPoint struct {
+	Date whoop.Date
+
+	// Load is the strain recorded on Date, or zero on days without data.
+	Load float64
+
+	// Acute and Chronic are the short- and long-term exponentially weighted
+	// averages of Load.
+	Acute   float64
+	Chronic float64
+
+	// Ratio is Acute / Chronic, or zero while Chronic is zero.
+	Ratio float64
+
+	// Spike reports whether Ratio reached the spike threshold. Spikes are only
+	// flagged once ChronicDays of history have accumulated, so the first weeks
+	// of data never raise false alarms.
+	Spike bool
+}
+
+// WorkoutStrainSeries sums the strain of scored workouts per local day of
+// their start time.
+func WorkoutStrainSeries(workouts []whoop.Workout) Series {
+	totals := make(map[whoop.Date]float64)
+	for i := range workouts {
+		w := &workouts[i]
+		if !w.IsScored() {
+			continue
+		}
+		date, err := w.LocalDate()
+		if err != nil {
+			continue
+		}
+		totals[date] += w.Score.Strain
+	}
+
+	samples := make([]Sample, 0, len(totals))
+	for date, total := range totals {
+		samples = append(samples, Sample{Date: date, Value: total})
+	}
+	return NewSeries(samples)
+}
+
+// TrainingLoad computes acute and chronic training load from a daily strain
+// series, such as CycleSeries(cycles, Strain) or WorkoutStrainSeries(workouts).
+// Every calendar day between the first and last sample is included, and days
+// missing from daily are treated as rest days with zero load. Both averages
+// are seeded with the first day's load and use the smoothing factor
+// 2 / (days + 1).
+func TrainingLoad(daily Series, opts TrainingLoadOptions) []LoadPoint {
+	if len(daily) == 0 {
+		return nil
+	}
+	opts = opts.withDefaults()
+
+	acuteAlpha := 2 / float64(opts.AcuteDays+1)
+	chronicAlpha := 2 / float64(opts.ChronicDays+1)
+
+	first, last := daily[0].Date, daily[len(daily)-1].Date
+	out := make([]LoadPoint, 0, last.DaysSince(first)+1)
+
+	var acute, chronic float64
+	for i, date := 0, first; !date.After(last); i, date = i+1, date.AddDays(1) {
+		load, _ := daily.Lookup(date)
+
+		if i == 0 {
+			acute, chronic = load, load
+		} else {
+			acute += acuteAlpha * (load - acute)
+			chronic += chronicAlpha * (load - chronic)
+		}
+
+		p := LoadPoint{Date: date, Load: load, Acute: acute, Chronic: chronic}
+		if chronic > 0 {
+			p.Ratio = acute / chronic
+		}
+		p.Spike = i >= opts.ChronicDays-1 && p.Ratio >= opts.SpikeRatio
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+func TestTrainingLoad_SteadyState(t *testing.T) {
+	var daily Series
+	for i := 0; i < 40; i++ {
+		daily = append(daily, Sample{Date: day0.AddDays(i), Value: 10})
+	}
+
+	points := TrainingLoad(daily, TrainingLoadOptions{})
+	if len(points) != 40 {
+		t.Fatalf("expected 40 points, got %d", len(points))
+	}
+	for _, p := range points {
+		if p.Acute != 10 || p.Chronic != 10 || p.Ratio != 1 || p.Spike {
+			t.Fatalf("expected steady load of 10 with ratio 1, got %+v", p)
+		}
+	}
+}
+
+func TestTrainingLoad_SpikeAndRestDays(t *testing.T) {
+	var daily Series
+	for i := 0; i < 28; i++ {
+		if i%2 == 0 { // every other day is a rest day (missing from the series)
+			daily = append(daily, Sample{Date: day0.AddDays(i), Value: 8})
+		}
+	}
+	for i := 28; i < 32; i++ {
+		daily = append(daily, Sample{Date: day0.AddDays(i), Value: 18})
+	}
+
+	points := TrainingLoad(daily, TrainingLoadOptions{AcuteDays: 7, ChronicDays: 28, SpikeRatio: 1.5})
+	if len(points) != 32 {
+		t.Fatalf("expected a point for every calendar day, got %d", len(points))
+	}
+	if points[1].Load != 0 {
+		t.Errorf("expected rest day to have zero load, got %f", points[1].Load)
+	}
+
+	// Hand-computed first two steps: acute seeded at 8, then 8 + 0.25*(0-8).
+	if points[1].Acute != 6 {
+		t.Errorf("expected acute 6 after a rest day, got %f", points[1].Acute)
+	}
+	wantChronic := 8 + (2.0/29.0)*(0-8)
+	if math.Abs(points[1].Chronic-wantChronic) > 1e-12 {
+		t.Errorf("expected chronic %f, got %f", wantChronic, points[1].Chronic)
+	}
+
+	for _, p := range points[:28] {
+		if p.Spike {
+			t.Errorf("unexpected spike on %s (ratio %.2f)", p.Date, p.Ratio)
+		}
+	}
+	last := points[len(points)-1]
+	if !last.Spike || last.Ratio < 1.5 {
+		t.Errorf("expected a spike after the load jump, got %+v", last)
+	}
+}
+
+func TestTrainingLoad_Empty(t *testing.T) {
+	if points := TrainingLoad(nil, TrainingLoadOptions{}); points != nil {
+		t.Errorf("expected nil, got %+v", points)
+	}
+}
+
+func TestWorkoutStrainSeries(t *testing.T) {
+	at := func(hour int) time.Time { return day0.In(time.UTC).Add(time.Duration(hour) * time.Hour) }
+	scored := func(start time.Time, strain float64) whoop.Workout {
+		return whoop.Workout{
+			Start:          start,
+			End:            start.Add(time.Hour),
+			TimezoneOffset: "-08:00",
+			ScoreState:     whoop.ScoreStateScored,
+			Score:          &whoop.WorkoutScore{Strain: strain},
+		}
+	}
+
+	workouts := []whoop.Workout{
+		scored(at(16), 8),  // 08:00 local on day0
+		scored(at(26), 5),  // 18:00 local on day0
+		scored(at(32), 11), // 00:00 local on day0+1
+		{Start: at(40), TimezoneOffset: "-08:00", ScoreState: whoop.ScoreStatePendingScore},
+	}
+
+	s := WorkoutStrainSeries(workouts)
+	if len(s) != 2 {
+		t.Fatalf("expected 2 days, got %+v", s)
+	}
+	if s[0].Date != day0 || s[0].Value != 13 {
+		t.Errorf("expected 13 strain on %s, got %+v", day0, s[0])
+	}
+	if s[1].Date != day0.AddDays(1) || s[1].Value != 11 {
+		t.Errorf("expected 11 strain on next day, got %+v", s[1])
+	}
+}
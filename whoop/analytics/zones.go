@@ -0,0 +1,214 @@
+package analytics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// ZoneDistribution is the time spent in each heart rate zone, indexed from
+// zone zero (below 50% of max heart rate) to zone five (90% and above).
+type ZoneDistribution [6]time.Duration
+
+// Total returns the time across all zones.
+func (d ZoneDistribution) Total() time.Duration {
+	var total time.Duration
+	for _, z := range d {
+		total += z
+	}
+	return total
+}
+
+// Percentages returns the share of Total spent in each zone, from 0 to 100.
+func (d ZoneDistribution) Percentages() [6]float64 {
+	var out [6]float64
+	total := d.Total()
+	if total == 0 {
+		return out
+	}
+	for i, z := range d {
+		out[i] = 100 * float64(z) / float64(total)
+	}
+	return out
+}
+
+// Polarization groups the time spent in zones one through five into easy
+// (zones one and two), moderate (zone three) and hard (zones four and five)
+// intensity. Zone zero is not training time and is excluded.
+func (d ZoneDistribution) Polarization() Polarization {
+	training := d.Total() - d[0]
+	if training == 0 {
+		return Polarization{}
+	}
+	return Polarization{
+		Easy:     100 * float64(d[1]+d[2]) / float64(training),
+		Moderate: 100 * float64(d[3]) / float64(training),
+		Hard:     100 * float64(d[4]+d[5]) / float64(training),
+	}
+}
+
+// Polarization is the percentage of training time at easy, moderate and hard
+// intensity.
+type Polarization struct {
+	Easy     float64
+	Moderate float64
+	Hard     float64
+}
+
+// PolarizedTarget is a commonly recommended polarized distribution: about 80%
+// of training time easy, with most of the remainder hard rather than moderate.
+var PolarizedTarget = Polarization{Easy: 80, Moderate: 5, Hard: 15}
+
+// Diff returns p minus target for each intensity, in percentage points.
+// Positive values mean more time than the target at that intensity.
+func (p Polarization) Diff(target Polarization) Polarization {
+	return Polarization{
+		Easy:     p.Easy - target.Easy,
+		Moderate: p.Moderate - target.Moderate,
+		Hard:     p.Hard - target.Hard,
+	}
+}
+
+// Within reports whether every intensity of p is within tolerance
+// percentage points of target.
+func (p Polarization) Within(target Polarization, tolerance float64) bool {
+	d := p.Diff(target)
+	return math.Abs(d.Easy) <= tolerance && math.Abs(d.Moderate) <= tolerance && math.Abs(d.Hard) <= tolerance
+}
+
+// ZoneOptions configures the zone aggregation functions.
+type ZoneOptions struct {
+	// WeekStart is the first day of each week for ZonesByWeek. The zero value
+	// is Sunday.
+	WeekStart time.Weekday
+
+	// MaxHeartRate, typically BodyMeasurement.MaxHeartRate, is used to estimate
+	// the zone of scored workouts that lack zone durations. When zero, such
+	// workouts are skipped.
+	MaxHeartRate int
+}
+
+// ZoneSummary aggregates the zone distribution of a group of workouts.
+type ZoneSummary struct {
+	Zones ZoneDistribution
+
+	// Workouts is the number of workouts included in Zones.
+	Workouts int
+
+	// Estimated is the number of those workouts whose zones were estimated
+	// from their average heart rate.
+	Estimated int
+}
+
+func (s *ZoneSummary) add(zones ZoneDistribution, estimated bool) {
+	for i, z := range zones {
+		s.Zones[i] += z
+	}
+	s.Workouts++
+	if estimated {
+		s.Estimated++
+	}
+}
+
+// WeeklyZones is the zone summary of the workouts started in one local week.
+type WeeklyZones struct {
+	Start whoop.Date
+	ZoneSummary
+}
+
+// SportZones is the zone summary of the workouts of one sport.
+type SportZones struct {
+	Sport whoop.Sport
+	ZoneSummary
+}
+
+// WorkoutZones returns the zone distribution of a scored workout. When the
+// workout has no zone durations and maxHeartRate is positive, its whole
+// duration is assigned to the zone of its average heart rate, and estimated is
+// true. ok is false if the workout is unscored or its zones are unknown.
+func WorkoutZones(w *whoop.Workout, maxHeartRate int) (zones ZoneDistribution, estimated, ok bool) {
+	if !w.IsScored() {
+		return zones, false, false
+	}
+	if z := w.Score.ZoneDuration; z != nil {
+		return z.Zones(), false, true
+	}
+	if maxHeartRate <= 0 || w.Score.AverageHeartRate <= 0 {
+		return zones, false, false
+	}
+	zones[HeartRateZone(w.Score.AverageHeartRate, maxHeartRate)] = w.Duration()
+	return zones, true, true
+}
+
+// HeartRateZone returns the zone, from zero to five, of heartRate as a
+// percentage of maxHeartRate. Zones one through five start at 50%, 60%, 70%,
+// 80% and 90%.
+func HeartRateZone(heartRate, maxHeartRate int) int {
+	if maxHeartRate <= 0 {
+		return 0
+	}
+	pct := 100 * heartRate / maxHeartRate
+	return min(max(pct/10-4, 0), 5)
+}
+
+// ZonesByWeek aggregates workouts by the local week they started in, in week
+// order. Workouts with an invalid timezone offset are skipped.
+func ZonesByWeek(workouts []whoop.Workout, opts ZoneOptions) []WeeklyZones {
+	weeks := make(map[whoop.Date]*WeeklyZones)
+	for i := range workouts {
+		w := &workouts[i]
+		zones, estimated, ok := WorkoutZones(w, opts.MaxHeartRate)
+		if !ok {
+			continue
+		}
+		date, err := w.LocalDate()
+		if err != nil {
+			continue
+		}
+		start := WeekOf(date, opts.WeekStart)
+		wz, ok := weeks[start]
+		if !ok {
+			wz = &WeeklyZones{Start: start}
+			weeks[start] = wz
+		}
+		wz.add(zones, estimated)
+	}
+
+	out := make([]WeeklyZones, 0, len(weeks))
+	for _, wz := range weeks {
+		out = append(out, *wz)
+	}
+	slices.SortFunc(out, func(a, b WeeklyZones) int { return a.Start.Compare(b.Start) })
+	return out
+}
+
+// ZonesBySport aggregates workouts by sport, ordered by total zone time
+// descending and then by sport ID.
+func ZonesBySport(workouts []whoop.Workout, opts ZoneOptions) []SportZones {
+	sports := make(map[int]*SportZones)
+	for i := range workouts {
+		w := &workouts[i]
+		zones, estimated, ok := WorkoutZones(w, opts.MaxHeartRate)
+		if !ok {
+			continue
+		}
+		sz, ok := sports[w.SportID]
+		if !ok {
+			sz = &SportZones{Sport: w.Sport()}
+			sports[w.SportID] = sz
+		}
+		sz.add(zones, estimated)
+	}
+
+	out := make([]SportZones, 0, len(sports))
+	for _, sz := range sports {
+		out = append(out, *sz)
+	}
+	slices.SortFunc(out, func(a, b SportZones) int {
+		return cmp.Or(cmp.Compare(b.Zones.Total(), a.Zones.Total()), cmp.Compare(a.Sport.ID, b.Sport.ID))
+	})
+	return out
+}
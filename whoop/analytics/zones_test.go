@@ -0,0 +1,110 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// zoneWorkout builds a scored one-hour workout starting at 08:00 local time
+// (-08:00) on date. When minutes is nil, the workout has no zone durations.
+func zoneWorkout(sportID int, date whoop.Date, avgHR int, minutes []int) whoop.Workout {
+	start := date.In(time.UTC).Add(16 * time.Hour)
+	w := whoop.Workout{
+		SportID:        sportID,
+		Start:          start,
+		End:            start.Add(time.Hour),
+		TimezoneOffset: "-08:00",
+		ScoreState:     whoop.ScoreStateScored,
+		Score:          &whoop.WorkoutScore{AverageHeartRate: avgHR},
+	}
+	if minutes != nil {
+		ms := func(i int) int { return int((time.Duration(minutes[i]) * time.Minute).Milliseconds()) }
+		w.Score.ZoneDuration = &whoop.ZoneDurations{
+			ZoneZeroMilli:  ms(0),
+			ZoneOneMilli:   ms(1),
+			ZoneTwoMilli:   ms(2),
+			ZoneThreeMilli: ms(3),
+			ZoneFourMilli:  ms(4),
+			ZoneFiveMilli:  ms(5),
+		}
+	}
+	return w
+}
+
+func TestHeartRateZone(t *testing.T) {
+	tests := []struct {
+		hr, want int
+	}{
+		{90, 0}, {100, 1}, {119, 1}, {120, 2}, {150, 3}, {170, 4}, {180, 5}, {210, 5},
+	}
+	for _, tt := range tests {
+		if got := HeartRateZone(tt.hr, 200); got != tt.want {
+			t.Errorf("HeartRateZone(%d, 200) = %d, want %d", tt.hr, got, tt.want)
+		}
+	}
+	if got := HeartRateZone(150, 0); got != 0 {
+		t.Errorf("expected zone zero without a max heart rate, got %d", got)
+	}
+}
+
+func TestZoneDistribution_Polarization(t *testing.T) {
+	d := ZoneDistribution{10 * time.Minute, 30 * time.Minute, 50 * time.Minute, 0, 15 * time.Minute, 5 * time.Minute}
+
+	if d.Total() != 110*time.Minute {
+		t.Errorf("unexpected total %v", d.Total())
+	}
+	p := d.Polarization()
+	if p.Easy != 80 || p.Moderate != 0 || p.Hard != 20 {
+		t.Errorf("expected 80/0/20 polarization, got %+v", p)
+	}
+	diff := p.Diff(PolarizedTarget)
+	if diff.Moderate != -5 || diff.Hard != 5 {
+		t.Errorf("unexpected diff against target: %+v", diff)
+	}
+	if !p.Within(PolarizedTarget, 5) || p.Within(PolarizedTarget, 4) {
+		t.Error("expected polarization to be within 5 but not 4 points of the target")
+	}
+	if got := (ZoneDistribution{time.Hour}).Polarization(); got != (Polarization{}) {
+		t.Errorf("expected zero polarization without training time, got %+v", got)
+	}
+}
+
+func TestZonesByWeekAndSport(t *testing.T) {
+	// day0 is a Sunday.
+	workouts := []whoop.Workout{
+		zoneWorkout(0, day0, 140, []int{0, 20, 30, 10, 0, 0}),                            // running
+		zoneWorkout(1, day0.AddDays(2), 130, []int{5, 25, 30, 0, 0, 0}),                  // cycling
+		zoneWorkout(0, day0.AddDays(8), 170, nil),                                        // running, estimated zone 4
+		zoneWorkout(0, day0.AddDays(9), 0, nil),                                          // no zones and no average heart rate
+		{SportID: 0, ScoreState: whoop.ScoreStatePendingScore, Start: day0.In(time.UTC)}, // unscored
+	}
+
+	weeks := ZonesByWeek(workouts, ZoneOptions{MaxHeartRate: 200})
+	if len(weeks) != 2 {
+		t.Fatalf("expected 2 weeks, got %+v", weeks)
+	}
+	if weeks[0].Start != day0 || weeks[0].Workouts != 2 || weeks[0].Zones[2] != time.Hour {
+		t.Errorf("unexpected first week: %+v", weeks[0])
+	}
+	if weeks[1].Workouts != 1 || weeks[1].Estimated != 1 || weeks[1].Zones[4] != time.Hour {
+		t.Errorf("expected estimated zone four hour in second week, got %+v", weeks[1])
+	}
+
+	mondayWeeks := ZonesByWeek(workouts, ZoneOptions{WeekStart: time.Monday})
+	if len(mondayWeeks) != 2 || mondayWeeks[0].Start != day0.AddDays(-6) || mondayWeeks[1].Workouts != 1 {
+		t.Errorf("unexpected Monday weeks without max heart rate: %+v", mondayWeeks)
+	}
+
+	sports := ZonesBySport(workouts, ZoneOptions{MaxHeartRate: 200})
+	if len(sports) != 2 {
+		t.Fatalf("expected 2 sports, got %+v", sports)
+	}
+	if sports[0].Sport.Name != "Running" || sports[0].Workouts != 2 || sports[0].Zones.Total() != 2*time.Hour {
+		t.Errorf("unexpected running summary: %+v", sports[0])
+	}
+	if sports[1].Sport.Name != "Cycling" || sports[1].Zones[0] != 5*time.Minute {
+		t.Errorf("unexpected cycling summary: %+v", sports[1])
+	}
+}
@@ -0,0 +1,85 @@
+package whoop
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of in-flight requests a GetMany call
+// issues when no WithBatchConcurrency option is provided.
+const defaultBatchConcurrency = 4
+
+// BatchResult holds the outcome of a GetMany call. Every unique requested ID
+// appears in exactly one of Records or Errors.
+type BatchResult[K comparable, T any] struct {
+	// Records maps each successfully fetched ID to its record.
+	Records map[K]*T
+
+	// Errors maps each ID that could not be fetched to the error returned for it,
+	// such as a *NotFoundError for unknown IDs; check for it with IsNotFound.
+	Errors map[K]error
+}
+
+// getMany fans out fetch over ids with at most concurrency requests in flight.
+// Duplicate IDs are fetched once. Each request still passes through Client.Do,
+// so the shared rate limiter and retry policy apply to every call. IDs that are
+// never started because ctx was canceled are reported with the context error.
+func getMany[K comparable, T any](ctx context.Context, concurrency int, ids []K, fetch func(context.Context, K) (*T, error)) *BatchResult[K, T] {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	res := &BatchResult[K, T]{
+		Records: make(map[K]*T, len(ids)),
+		Errors:  make(map[K]error),
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, concurrency)
+		seen = make(map[K]struct{}, len(ids))
+	)
+
+	record := func(id K, item *T, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			res.Errors[id] = err
+			return
+		}
+		res.Records[id] = item
+	}
+
+	for _, id := range ids {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		// Check cancellation first so a canceled context never races a free slot.
+		if err := ctx.Err(); err != nil {
+			record(id, nil, err)
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			record(id, nil, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			item, err := fetch(ctx, id)
+			record(id, item, err)
+		}()
+	}
+
+	wg.Wait()
+	return res
+}
@@ -0,0 +1,122 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkoutService_GetMany(t *testing.T) {
+	ts := newMockServer(t)
+	defer ts.Close()
+
+	client := newMockClient(ts)
+
+	res := client.Workout.GetMany(context.Background(), []string{"wkt-uuid-456", "missing-id", "wkt-uuid-456"})
+
+	if len(res.Records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(res.Records))
+	}
+	if w := res.Records["wkt-uuid-456"]; w == nil || w.ID != "wkt-uuid-456" {
+		t.Errorf("expected workout wkt-uuid-456, got %+v", w)
+	}
+
+	if len(res.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(res.Errors))
+	}
+	var apiErr *APIError
+	if !errors.As(res.Errors["missing-id"], &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError for missing-id, got %v", res.Errors["missing-id"])
+	}
+	if !IsNotFound(res.Errors["missing-id"]) {
+		t.Errorf("expected a NotFoundError for missing-id, got %T", res.Errors["missing-id"])
+	}
+}
+
+func TestGetMany_AllServices(t *testing.T) {
+	ts := newMockServer(t)
+	defer ts.Close()
+
+	client := newMockClient(ts)
+	ctx := context.Background()
+
+	if res := client.Cycle.GetMany(ctx, []int{123}); res.Records[123] == nil {
+		t.Errorf("expected cycle 123, got errors %v", res.Errors)
+	}
+	if res := client.Sleep.GetMany(ctx, []string{"slp-uuid-789"}); res.Records["slp-uuid-789"] == nil {
+		t.Errorf("expected sleep slp-uuid-789, got errors %v", res.Errors)
+	}
+	if res := client.Recovery.GetManyByCycle(ctx, []int{123}); res.Records[123] == nil {
+		t.Errorf("expected recovery for cycle 123, got errors %v", res.Errors)
+	}
+}
+
+func TestGetMany_BoundedConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/cycle/")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id": %s}`, id)
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL), WithBatchConcurrency(2))
+
+	ids := []int{1, 2, 3, 4, 5, 6}
+	res := client.Cycle.GetMany(context.Background(), ids)
+
+	if len(res.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", res.Errors)
+	}
+	for _, id := range ids {
+		if c := res.Records[id]; c == nil || c.ID != id {
+			t.Errorf("expected cycle %d, got %+v", id, c)
+		}
+	}
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, observed %d", got)
+	}
+}
+
+func TestGetMany_CanceledContext(t *testing.T) {
+	var calls atomic.Int32
+	fetch := func(ctx context.Context, id int) (*Cycle, error) {
+		calls.Add(1)
+		return &Cycle{ID: id}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := getMany(ctx, 0, []int{1, 2, 3}, fetch)
+
+	if calls.Load() != 0 {
+		t.Errorf("expected no fetches after cancellation, got %d", calls.Load())
+	}
+	if len(res.Errors) != 3 {
+		t.Fatalf("expected 3 errors, got %d", len(res.Errors))
+	}
+	for id, err := range res.Errors {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled for id %d, got %v", id, err)
+		}
+	}
+}
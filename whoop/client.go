@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,8 +30,18 @@ type Client struct {
 	backoffBase time.Duration
 	backoffMax  time.Duration
 
+	batchConcurrency int
+	strictDecoding   bool
+
+	// scopes are the scopes granted to the token, or nil if unknown.
+	scopes map[Scope]struct{}
+
 	rateLimiter *rateLimiter
 
+	// rateLimitRemaining is the last X-RateLimit-Remaining value reported by
+	// the API, or -1 before any response carried the header.
+	rateLimitRemaining atomic.Int64
+
 	// Services used for communicating with the WHOOP API endpoints.
 	User     *UserService
 	Cycle    *CycleService
@@ -47,9 +58,14 @@ func NewClient(opts ...Option) *Client {
 		maxRetries:  3,
 		backoffBase: 1 * time.Second,
 		backoffMax:  60 * time.Second,
+
+		batchConcurrency: defaultBatchConcurrency,
+
 		rateLimiter: newRateLimiter(),
 	}
 
+	c.rateLimitRemaining.Store(-1)
+
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -102,6 +118,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 			// but for now, we only retry explicitly on 429s.
 			return nil, fmt.Errorf("http execute request failed: %w", err)
 		}
+		c.recordRateLimitRemaining(resp)
 
 		// Success or non-retryable error, break loop.
 		if resp.StatusCode != http.StatusTooManyRequests {
@@ -159,6 +176,24 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	return resp, nil
 }
 
+// RateLimitRemaining returns the number of requests the WHOOP API reported as
+// remaining in the current rate limit window, as of the most recent response
+// that carried an X-RateLimit-Remaining header. ok is false until then.
+func (c *Client) RateLimitRemaining() (remaining int, ok bool) {
+	v := c.rateLimitRemaining.Load()
+	if v < 0 {
+		return 0, false
+	}
+	return int(v), true
+}
+
+// recordRateLimitRemaining stores the X-RateLimit-Remaining header of resp, if valid.
+func (c *Client) recordRateLimitRemaining(resp *http.Response) {
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && v >= 0 {
+		c.rateLimitRemaining.Store(int64(v))
+	}
+}
+
 // Get performs a GET request to the specified path and decodes the response into v.
 func (c *Client) Get(ctx context.Context, path string, v any) (err error) {
 	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
@@ -177,8 +212,21 @@ func (c *Client) Get(ctx context.Context, path string, v any) (err error) {
 	}()
 
 	if v != nil {
-		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
-			return err
+		return c.decode(resp, v)
+	}
+	return nil
+}
+
+// decode decodes the JSON body of resp into v. In strict decoding mode it
+// fails with an *UnknownFieldsError if the body contained members that the
+// model types do not define.
+func (c *Client) decode(resp *http.Response, v any) error {
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return err
+	}
+	if c.strictDecoding {
+		if fields := unknownFields(v); len(fields) > 0 {
+			return &UnknownFieldsError{URL: resp.Request.URL.String(), Fields: fields}
 		}
 	}
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -58,6 +59,39 @@ func TestClient_Do_CustomErrorMapping(t *testing.T) {
 	}
 }
 
+func TestClient_RateLimitRemaining(t *testing.T) {
+	remaining := []string{"", "42", "bogus"}
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := remaining[calls]; v != "" {
+			w.Header().Set("X-RateLimit-Remaining", v)
+		}
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL), WithRateLimiting(false))
+	if _, ok := client.RateLimitRemaining(); ok {
+		t.Error("expected no value before any request")
+	}
+
+	_ = client.Get(context.Background(), "/", nil)
+	if _, ok := client.RateLimitRemaining(); ok {
+		t.Error("expected no value after a response without the header")
+	}
+
+	_ = client.Get(context.Background(), "/", nil)
+	if v, ok := client.RateLimitRemaining(); !ok || v != 42 {
+		t.Errorf("expected 42 remaining, got %d (%v)", v, ok)
+	}
+
+	_ = client.Get(context.Background(), "/", nil)
+	if v, ok := client.RateLimitRemaining(); !ok || v != 42 {
+		t.Errorf("expected an invalid header to be ignored, got %d (%v)", v, ok)
+	}
+}
+
 func TestClientStringRedaction(t *testing.T) {
 	token := "my-secret-token"
 	client := &Client{
@@ -2,6 +2,7 @@ package whoop
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -15,8 +16,16 @@ type Cycle struct {
 	Start          time.Time  `json:"start"`
 	End            *time.Time `json:"end"`
 	TimezoneOffset string     `json:"timezone_offset"`
-	ScoreState     string     `json:"score_state"`
+	ScoreState     ScoreState `json:"score_state"`
 	Score          *Score     `json:"score,omitempty"`
+
+	// Extra holds the JSON members not mapped to a field of Cycle.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IsScored reports whether the cycle has been scored and its Score is populated.
+func (c *Cycle) IsScored() bool {
+	return c.ScoreState.IsScored() && c.Score != nil
 }
 
 // Score summarizes physiological strains within a Cycle.
@@ -25,8 +34,14 @@ type Score struct {
 	Kilojoule        float64 `json:"kilojoule"`
 	AverageHeartRate int     `json:"average_heart_rate"`
 	MaxHeartRate     int     `json:"max_heart_rate"`
+
+	// Extra holds the JSON members not mapped to a field of Score.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// CyclePath is the API path of the cycle collection.
+const CyclePath = "/cycle"
+
 // CycleService handles communication with the cycle related methods.
 type CycleService struct {
 	client *Client
@@ -34,17 +49,40 @@ type CycleService struct {
 
 // GetByID fetches a single cycle by its ID.
 func (s *CycleService) GetByID(ctx context.Context, id int) (*Cycle, error) {
+	if err := s.client.requireScope(ScopeReadCycles); err != nil {
+		return nil, err
+	}
 	var cycle Cycle
-	if err := s.client.Get(ctx, fmt.Sprintf("/cycle/%d", id), &cycle); err != nil {
+	if err := s.client.Get(ctx, fmt.Sprintf(CyclePath+"/%d", id), &cycle); err != nil {
 		return nil, err
 	}
 
 	return &cycle, nil
 }
 
+// GetMany fetches multiple cycles by ID concurrently, bounded by the client's
+// batch concurrency. Per-ID failures, including 404s for unknown IDs, are
+// reported in the result's Errors map without failing the whole batch.
+func (s *CycleService) GetMany(ctx context.Context, ids []int) *BatchResult[int, Cycle] {
+	return getMany(ctx, s.client.batchConcurrency, ids, s.GetByID)
+}
+
+// WaitForScore polls GetByID every pollInterval until the cycle is scored or
+// unscorable, which is typically needed right after a webhook event arrives.
+// A non-positive pollInterval defaults to 5 seconds.
+func (s *CycleService) WaitForScore(ctx context.Context, id int, pollInterval time.Duration) (*Cycle, error) {
+	return waitForScore(ctx, pollInterval,
+		func(ctx context.Context) (*Cycle, error) { return s.GetByID(ctx, id) },
+		func(item *Cycle) ScoreState { return item.ScoreState },
+	)
+}
+
 // List fetches a paginated collection of cycles.
 func (s *CycleService) List(ctx context.Context, opts *ListOptions) (*CyclePage, error) {
-	page, err := getPaginated[Cycle](ctx, s.client, "/cycle", opts)
+	if err := s.client.requireScope(ScopeReadCycles); err != nil {
+		return nil, err
+	}
+	page, err := getPaginated[Cycle](ctx, s.client, CyclePath, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,146 @@
+package whoop
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// daysPageLimit is the page size used when Days walks each collection.
+	daysPageLimit = 25
+
+	// daysSleepLookback widens the sleep and recovery window before the first
+	// cycle so the sleep that precedes it is still fetched.
+	daysSleepLookback = 24 * time.Hour
+)
+
+// Day joins the records WHOOP links to a single physiological cycle. Pieces
+// that WHOOP has not produced are left nil or empty rather than guessed, and
+// pieces that exist but are not yet scored keep their ScoreState, so callers
+// can tell "missing" from "pending" explicitly.
+type Day struct {
+	// Cycle is the physiological cycle the day is built around.
+	Cycle Cycle
+
+	// Recovery is the recovery linked to the cycle, or nil if there is none.
+	Recovery *Recovery
+
+	// Sleep is the cycle's main sleep, or nil if none was recorded. It is the
+	// sleep referenced by Recovery.SleepID when available, otherwise the
+	// non-nap sleep linked to the cycle via Sleep.CycleID.
+	Sleep *Sleep
+
+	// Naps are the nap sleeps linked to the cycle, ordered by start time.
+	Naps []Sleep
+
+	// Workouts are the workouts overlapping the cycle, ordered by start time.
+	// A workout spanning the boundary between two cycles appears in both.
+	Workouts []Workout
+}
+
+// LocalDate returns the local calendar day of the day's cycle.
+func (d *Day) LocalDate() (Date, error) {
+	return d.Cycle.LocalDate()
+}
+
+// IsComplete reports whether the cycle, recovery and main sleep are all
+// present and scored. Workouts and naps are optional and not considered.
+func (d *Day) IsComplete() bool {
+	return d.Cycle.IsScored() &&
+		d.Recovery != nil && d.Recovery.IsScored() &&
+		d.Sleep != nil && d.Sleep.IsScored()
+}
+
+// Days fetches the cycles starting between start and end, along with the
+// recoveries, sleeps and workouts linked to them, and assembles one Day per
+// cycle ordered by cycle start. Every page of each collection is fetched, so
+// long ranges issue many requests; all of them honor the client rate limiter.
+func (c *Client) Days(ctx context.Context, start, end time.Time) ([]Day, error) {
+	if err := c.requireScope(ScopeReadCycles, ScopeReadRecovery, ScopeReadSleep, ScopeReadWorkout); err != nil {
+		return nil, err
+	}
+	cycles, err := listAll[Cycle](ctx, c, CyclePath, &ListOptions{Limit: daysPageLimit, Start: &start, End: &end})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cycles: %w", err)
+	}
+	if len(cycles) == 0 {
+		return nil, nil
+	}
+
+	slices.SortFunc(cycles, func(a, b Cycle) int { return a.Start.Compare(b.Start) })
+
+	// Fetch the linked collections over the span the cycles actually cover.
+	// An open-ended final cycle leaves End unset so data up to now is included.
+	from := cycles[0].Start.Add(-daysSleepLookback)
+	linked := &ListOptions{Limit: daysPageLimit, Start: &from}
+	if last := cycles[len(cycles)-1]; last.End != nil {
+		linked.End = last.End
+	}
+
+	recoveries, err := listAll[Recovery](ctx, c, RecoveryPath, linked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recoveries: %w", err)
+	}
+	sleeps, err := listAll[Sleep](ctx, c, SleepPath, linked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sleeps: %w", err)
+	}
+	workouts, err := listAll[Workout](ctx, c, WorkoutPath, linked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workouts: %w", err)
+	}
+
+	return assembleDays(cycles, recoveries, sleeps, workouts), nil
+}
+
+// assembleDays joins records to cycles. cycles must be sorted by start time.
+func assembleDays(cycles []Cycle, recoveries []Recovery, sleeps []Sleep, workouts []Workout) []Day {
+	recoveryByCycle := make(map[int]*Recovery, len(recoveries))
+	for i := range recoveries {
+		recoveryByCycle[recoveries[i].CycleID] = &recoveries[i]
+	}
+
+	slices.SortFunc(sleeps, func(a, b Sleep) int { return a.Start.Compare(b.Start) })
+	slices.SortFunc(workouts, func(a, b Workout) int { return a.Start.Compare(b.Start) })
+
+	sleepByID := make(map[string]*Sleep, len(sleeps))
+	sleepsByCycle := make(map[int][]*Sleep)
+	for i := range sleeps {
+		s := &sleeps[i]
+		sleepByID[s.ID] = s
+		sleepsByCycle[s.CycleID] = append(sleepsByCycle[s.CycleID], s)
+	}
+
+	days := make([]Day, 0, len(cycles))
+	for _, cycle := range cycles {
+		day := Day{Cycle: cycle, Recovery: recoveryByCycle[cycle.ID]}
+
+		if day.Recovery != nil && day.Recovery.SleepID != "" {
+			day.Sleep = sleepByID[day.Recovery.SleepID]
+		}
+
+		linked := day.Sleep != nil
+		for _, s := range sleepsByCycle[cycle.ID] {
+			if s.Nap {
+				day.Naps = append(day.Naps, *s)
+				continue
+			}
+			// Without a recovery link, the longest non-nap sleep is the main sleep.
+			if !linked && (day.Sleep == nil || s.Duration() > day.Sleep.Duration()) {
+				day.Sleep = s
+			}
+		}
+
+		for _, w := range workouts {
+			if w.End.After(cycle.Start) && (cycle.End == nil || w.Start.Before(*cycle.End)) {
+				day.Workouts = append(day.Workouts, w)
+			}
+		}
+
+		days = append(days, day)
+	}
+
+	return days
+}
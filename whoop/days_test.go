@@ -0,0 +1,170 @@
+package whoop
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newDaysServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/cycle", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("nextToken") {
+		case "":
+			// The API returns newest first; Days must reorder.
+			_, _ = w.Write([]byte(`{
+				"records": [
+					{"id": 2, "start": "2026-02-25T14:00:00Z", "timezone_offset": "-08:00", "score_state": "PENDING_SCORE"}
+				],
+				"next_token": "cycles-p2"
+			}`))
+		case "cycles-p2":
+			_, _ = w.Write([]byte(`{
+				"records": [
+					{"id": 1, "start": "2026-02-24T14:00:00Z", "end": "2026-02-25T14:00:00Z", "timezone_offset": "-08:00", "score_state": "SCORED", "score": {"strain": 12.4}}
+				],
+				"next_token": ""
+			}`))
+		}
+	})
+
+	mux.HandleFunc("/recovery", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") != "2026-02-23T14:00:00Z" {
+			t.Errorf("expected linked window to start a day before the first cycle, got %s", r.URL.Query().Get("start"))
+		}
+		if r.URL.Query().Get("end") != "" {
+			t.Errorf("expected open-ended linked window, got end=%s", r.URL.Query().Get("end"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"records": [
+				{"cycle_id": 1, "sleep_id": "sleep-a", "score_state": "SCORED", "score": {"recovery_score": 80}}
+			],
+			"next_token": ""
+		}`))
+	})
+
+	mux.HandleFunc("/activity/sleep", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"records": [
+				{"id": "sleep-a", "cycle_id": 0, "start": "2026-02-24T06:00:00Z", "end": "2026-02-24T14:00:00Z", "timezone_offset": "-08:00", "nap": false, "score_state": "SCORED", "score": {}},
+				{"id": "nap-1", "cycle_id": 1, "start": "2026-02-24T22:00:00Z", "end": "2026-02-24T22:30:00Z", "timezone_offset": "-08:00", "nap": true, "score_state": "SCORED", "score": {}},
+				{"id": "sleep-b", "cycle_id": 2, "start": "2026-02-25T06:00:00Z", "end": "2026-02-25T14:00:00Z", "timezone_offset": "-08:00", "nap": false, "score_state": "PENDING_SCORE"}
+			],
+			"next_token": ""
+		}`))
+	})
+
+	mux.HandleFunc("/activity/workout", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"records": [
+				{"id": "wkt-late", "start": "2026-02-25T16:00:00Z", "end": "2026-02-25T17:00:00Z", "sport_id": 0},
+				{"id": "wkt-early", "start": "2026-02-24T18:00:00Z", "end": "2026-02-24T19:00:00Z", "sport_id": 45}
+			],
+			"next_token": ""
+		}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestClient_Days(t *testing.T) {
+	ts := newDaysServer(t)
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL))
+
+	start := time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 26, 0, 0, 0, 0, time.UTC)
+	days, err := client.Days(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(days))
+	}
+
+	first, second := days[0], days[1]
+
+	if first.Cycle.ID != 1 || second.Cycle.ID != 2 {
+		t.Fatalf("expected days ordered by cycle start, got %d then %d", first.Cycle.ID, second.Cycle.ID)
+	}
+
+	if first.Recovery == nil || first.Recovery.Score.RecoveryScore != 80 {
+		t.Errorf("expected recovery for first day, got %+v", first.Recovery)
+	}
+	if first.Sleep == nil || first.Sleep.ID != "sleep-a" {
+		t.Errorf("expected recovery-linked main sleep sleep-a, got %+v", first.Sleep)
+	}
+	if len(first.Naps) != 1 || first.Naps[0].ID != "nap-1" {
+		t.Errorf("expected nap-1, got %+v", first.Naps)
+	}
+	if len(first.Workouts) != 1 || first.Workouts[0].ID != "wkt-early" {
+		t.Errorf("expected wkt-early, got %+v", first.Workouts)
+	}
+	if !first.IsComplete() {
+		t.Error("expected first day to be complete")
+	}
+	if d, err := first.LocalDate(); err != nil || d.String() != "2026-02-24" {
+		t.Errorf("expected local date 2026-02-24, got %s (%v)", d, err)
+	}
+
+	if second.Recovery != nil {
+		t.Errorf("expected no recovery for second day, got %+v", second.Recovery)
+	}
+	if second.Sleep == nil || second.Sleep.ID != "sleep-b" {
+		t.Errorf("expected cycle-linked main sleep sleep-b, got %+v", second.Sleep)
+	}
+	if len(second.Workouts) != 1 || second.Workouts[0].ID != "wkt-late" {
+		t.Errorf("expected wkt-late in open cycle, got %+v", second.Workouts)
+	}
+	if second.IsComplete() {
+		t.Error("expected second day to be incomplete")
+	}
+}
+
+func TestClient_Days_NoCycles(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cycle" {
+			t.Errorf("expected no requests beyond /cycle, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"records": [], "next_token": ""}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL))
+
+	days, err := client.Days(context.Background(), time.Now().Add(-24*time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 0 {
+		t.Errorf("expected no days, got %d", len(days))
+	}
+}
+
+func TestAssembleDays_LongestSleepWithoutRecovery(t *testing.T) {
+	base := time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+	cycles := []Cycle{{ID: 7, Start: base}}
+	sleeps := []Sleep{
+		{ID: "short", CycleID: 7, Start: base.Add(2 * time.Hour), End: base.Add(3 * time.Hour)},
+		{ID: "long", CycleID: 7, Start: base.Add(16 * time.Hour), End: base.Add(24 * time.Hour)},
+	}
+
+	days := assembleDays(cycles, nil, sleeps, nil)
+	if len(days) != 1 || days[0].Sleep == nil || days[0].Sleep.ID != "long" {
+		t.Fatalf("expected longest sleep as main sleep, got %+v", days)
+	}
+	if days[0].Recovery != nil || len(days[0].Workouts) != 0 {
+		t.Errorf("expected missing pieces to stay empty, got %+v", days[0])
+	}
+}
@@ -12,6 +12,18 @@
 //
 //	profile, err := client.User.GetBasicProfile(ctx)
 //
+// # Scopes
+//
+// A client that knows its token's granted scopes fails fast with a
+// *MissingScopeError instead of a 403 from the API:
+//
+//	client := whoop.NewClient(
+//	    whoop.WithToken(tok.AccessToken),
+//	    whoop.WithScopes(whoop.ParseScopes(tok.Scope)...),
+//	)
+//
+// ScopesFor lists the scopes to request for the services an application uses.
+//
 // # Pagination
 //
 // List methods return page objects with a NextPage iterator:
@@ -25,9 +37,44 @@
 //	    }
 //	}
 //
+// # Errors
+//
+// Failed requests return typed errors: *AuthError, *RateLimitError,
+// *NotFoundError, *ValidationError and *ServerError, each wrapping an
+// *APIError that holds the status, body, and the request and trace IDs to
+// quote when contacting WHOOP support:
+//
+//	rec, err := client.Recovery.GetByID(ctx, cycleID)
+//	if whoop.IsNotFound(err) {
+//	    // The cycle has not been scored yet.
+//	}
+//
+// IsRetryable reports whether a failed request may succeed later.
+//
+// # Unknown Fields
+//
+// Every model and score type has an Extra field. Decoding stores the JSON
+// members the type does not define there, and encoding writes them back, so
+// fields WHOOP adds after this release survive a decode and re-encode, for
+// example into an archive. WithStrictDecoding turns unknown fields into an
+// *UnknownFieldsError instead. Because Extra is a map, model values cannot be
+// compared with ==; compare their fields or use reflect.DeepEqual.
+//
 // # Webhooks
 //
 // Use ParseWebhook to validate and decode incoming WHOOP webhook payloads:
 //
 //	event, err := whoop.ParseWebhook(r, "webhook_secret")
+//
+// # Many Users
+//
+// A Manager syncs many users' data through one rate limiter, building a
+// client per user from a TokenStore. A user whose consent was revoked fails
+// with an *AuthError and is skipped by later runs, without stopping the rest:
+//
+//	m := whoop.NewManager(tokens, whoop.WithMaxConcurrentUsers(8))
+//	res, err := m.Run(ctx, func(ctx context.Context, userID int, c *whoop.Client) error {
+//	    _, err := c.Recovery.List(ctx, &whoop.ListOptions{Limit: 25})
+//	    return err
+//	})
 package whoop
@@ -1,22 +1,48 @@
 package whoop
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 )
 
-// APIError represents an error returned by the WHOOP API.
+// requestIDHeaders and traceIDHeaders are the response headers, in order of
+// preference, that identify a request to WHOOP support.
+var (
+	requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid"}
+	traceIDHeaders   = []string{"X-Trace-Id", "X-Amzn-Trace-Id", "X-B3-Traceid", "Traceparent"}
+)
+
+// APIError represents an error returned by the WHOOP API. Every error mapped
+// from an HTTP response wraps one, so errors.As can always reach the request
+// and trace IDs to quote in a support ticket.
 type APIError struct {
 	StatusCode int
 	Message    string
 	URL        string
 	Err        error // Underlying error, if any
+
+	// RequestID and TraceID identify the request, if the response carried
+	// a request ID header or a tracing header.
+	RequestID string
+	TraceID   string
+
+	// Header holds the response headers.
+	Header http.Header
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
 	msg := fmt.Sprintf("whoop api error: %d - %s at %s", e.StatusCode, e.Message, e.URL)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request id %s]", e.RequestID)
+	}
 	if e.Err != nil {
 		msg += fmt.Sprintf(" (%v)", e.Err)
 	}
@@ -72,6 +98,137 @@ func (e *AuthError) Unwrap() error {
 	return e.Err
 }
 
+// NotFoundError represents a 404 for a resource that does not exist, such as
+// a recovery for a cycle that has not been scored.
+type NotFoundError struct {
+	URL string
+	Err error
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("whoop resource not found at %s", e.URL)
+	if e.Err != nil {
+		msg += fmt.Sprintf(" - %v", e.Err)
+	}
+	return msg
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes one invalid request field reported by the API.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError represents a rejected request (400, 422). Message and
+// Fields are parsed from the API's JSON error body when it has one;
+// otherwise Message is the raw body.
+type ValidationError struct {
+	StatusCode int
+	Message    string
+	Fields     []FieldError
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("whoop validation error (%d): %s", e.StatusCode, e.Message)
+	if len(e.Fields) > 0 {
+		fields := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			fields[i] = f.Field + ": " + f.Message
+		}
+		msg += " (" + strings.Join(fields, "; ") + ")"
+	}
+	if e.Err != nil {
+		msg += fmt.Sprintf(" - %v", e.Err)
+	}
+	return msg
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ServerError represents a failure on WHOOP's side (5xx).
+type ServerError struct {
+	StatusCode int
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *ServerError) Error() string {
+	msg := fmt.Sprintf("whoop server error (%d)", e.StatusCode)
+	if e.Err != nil {
+		msg += fmt.Sprintf(" - %v", e.Err)
+	}
+	return msg
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *ServerError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is or wraps a *NotFoundError.
+func IsNotFound(err error) bool {
+	var nfErr *NotFoundError
+	return errors.As(err, &nfErr)
+}
+
+// IsRetryable reports whether the request that failed with err may succeed
+// if sent again later: rate limits, server errors other than 501 Not
+// Implemented, and network timeouts. Canceled or expired contexts are never
+// retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return true
+	}
+	var srvErr *ServerError
+	if errors.As(err, &srvErr) {
+		return srvErr.StatusCode != http.StatusNotImplemented
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// UnknownFieldsError is returned in strict decoding mode when a response
+// contains fields the model types do not define. The response was otherwise
+// decoded successfully.
+type UnknownFieldsError struct {
+	URL string
+
+	// Fields are the paths of the unknown fields, such as "score.new_metric"
+	// or "records[0].score.new_metric".
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("whoop response from %s has unknown fields: %s", e.URL, strings.Join(e.Fields, ", "))
+}
+
+// MissingScopeError is returned without making a request when a client
+// configured with WithScopes calls a method whose scope was not granted.
+type MissingScopeError struct {
+	Scope Scope
+}
+
+// Error implements the error interface.
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("whoop token is missing the %q scope", e.Scope)
+}
+
 // mapHTTPError is a helper to convert an unsuccessful HTTP response to an appropriate custom error.
 func mapHTTPError(resp *http.Response, body []byte) error {
 	msg := string(body)
@@ -84,6 +241,9 @@ func mapHTTPError(resp *http.Response, body []byte) error {
 		StatusCode: resp.StatusCode,
 		Message:    msg,
 		URL:        resp.Request.URL.String(),
+		RequestID:  firstHeader(resp.Header, requestIDHeaders),
+		TraceID:    firstHeader(resp.Header, traceIDHeaders),
+		Header:     resp.Header.Clone(),
 	}
 
 	switch resp.StatusCode {
@@ -103,7 +263,87 @@ func mapHTTPError(resp *http.Response, body []byte) error {
 			}
 		}
 		return rlErr
+	case http.StatusNotFound:
+		return &NotFoundError{
+			URL: baseErr.URL,
+			Err: baseErr,
+		}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		vErr := &ValidationError{
+			StatusCode: resp.StatusCode,
+			Message:    msg,
+			Err:        baseErr,
+		}
+		parseValidationBody(body, vErr)
+		return vErr
 	default:
+		if resp.StatusCode >= 500 {
+			return &ServerError{
+				StatusCode: resp.StatusCode,
+				Err:        baseErr,
+			}
+		}
 		return baseErr
 	}
 }
+
+// firstHeader returns the value of the first of names set in h.
+func firstHeader(h http.Header, names []string) string {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseValidationBody fills in e's Message and Fields from a JSON error body.
+// It accepts a top-level "message" or "error" string and an "errors" value
+// that is either a list of objects with "field" and "message" or an object
+// mapping field names to a message or list of messages. Bodies that are not
+// JSON leave e unchanged.
+func parseValidationBody(body []byte, e *ValidationError) {
+	var payload struct {
+		Message string          `json:"message"`
+		Error   string          `json:"error"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return
+	}
+	switch {
+	case payload.Message != "":
+		e.Message = payload.Message
+	case payload.Error != "":
+		e.Message = payload.Error
+	}
+
+	var list []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(payload.Errors, &list) == nil {
+		for _, f := range list {
+			e.Fields = append(e.Fields, FieldError{Field: f.Field, Message: f.Message})
+		}
+		return
+	}
+
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(payload.Errors, &byField) != nil {
+		return
+	}
+	for field, raw := range byField {
+		var one string
+		var many []string
+		switch {
+		case json.Unmarshal(raw, &one) == nil:
+			e.Fields = append(e.Fields, FieldError{Field: field, Message: one})
+		case json.Unmarshal(raw, &many) == nil:
+			for _, m := range many {
+				e.Fields = append(e.Fields, FieldError{Field: field, Message: m})
+			}
+		}
+	}
+	slices.SortStableFunc(e.Fields, func(a, b FieldError) int { return strings.Compare(a.Field, b.Field) })
+}
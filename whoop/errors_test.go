@@ -1,10 +1,13 @@
 package whoop
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -135,6 +138,32 @@ func TestErrorsAs_TypeAssertions(t *testing.T) {
 	}
 }
 
+func TestIsRetryable(t *testing.T) {
+	timeout := &url.Error{Op: "Get", URL: "/test", Err: &net.DNSError{IsTimeout: true}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limit", &RateLimitError{RetryAfter: 5}, true},
+		{"server error", fmt.Errorf("list: %w", &ServerError{StatusCode: 503}), true},
+		{"auth error", &AuthError{StatusCode: 401}, false},
+		{"not found", &NotFoundError{URL: "/test"}, false},
+		{"network timeout", fmt.Errorf("http execute request failed: %w", timeout), true},
+		{"canceled", fmt.Errorf("request aborted by context: %w", context.Canceled), false},
+		{"deadline", fmt.Errorf("request aborted by context: %w", context.DeadlineExceeded), false},
+		{"plain", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMapHTTPError(t *testing.T) {
 	testURL, _ := url.Parse("https://api.whoop.com/test")
 
@@ -224,6 +253,9 @@ func TestMapHTTPError(t *testing.T) {
 			statusCode: http.StatusNotFound,
 			body:       "not found",
 			wantErr: func(t *testing.T, err error) {
+				if !IsNotFound(err) || IsRetryable(err) {
+					t.Errorf("expected a non-retryable NotFoundError, got %T", err)
+				}
 				var apiErr *APIError
 				if !errors.As(err, &apiErr) {
 					t.Fatalf("expected APIError, got %T", err)
@@ -241,6 +273,10 @@ func TestMapHTTPError(t *testing.T) {
 			statusCode: http.StatusInternalServerError,
 			body:       "internal error",
 			wantErr: func(t *testing.T, err error) {
+				var srvErr *ServerError
+				if !errors.As(err, &srvErr) || srvErr.StatusCode != 500 || !IsRetryable(err) {
+					t.Errorf("expected a retryable ServerError, got %v", err)
+				}
 				var apiErr *APIError
 				if !errors.As(err, &apiErr) {
 					t.Fatalf("expected APIError, got %T", err)
@@ -250,6 +286,89 @@ func TestMapHTTPError(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:       "501 Not Implemented",
+			statusCode: http.StatusNotImplemented,
+			body:       "not implemented",
+			wantErr: func(t *testing.T, err error) {
+				var srvErr *ServerError
+				if !errors.As(err, &srvErr) || IsRetryable(err) {
+					t.Errorf("expected a non-retryable ServerError, got %v", err)
+				}
+			},
+		},
+		{
+			name:       "400 Bad Request (field list)",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message":"invalid query","errors":[{"field":"limit","message":"must be at most 25"}]}`,
+			wantErr: func(t *testing.T, err error) {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) {
+					t.Fatalf("expected ValidationError, got %T", err)
+				}
+				want := []FieldError{{Field: "limit", Message: "must be at most 25"}}
+				if vErr.StatusCode != 400 || vErr.Message != "invalid query" || !slices.Equal(vErr.Fields, want) {
+					t.Errorf("unexpected validation error %+v", vErr)
+				}
+				if !strings.Contains(err.Error(), "limit: must be at most 25") {
+					t.Errorf("expected the field in the message, got %s", err)
+				}
+			},
+		},
+		{
+			name:       "422 Unprocessable Entity (field map)",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"error":"validation failed","errors":{"start":"must be before end","end":["is in the future","is invalid"]}}`,
+			wantErr: func(t *testing.T, err error) {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) {
+					t.Fatalf("expected ValidationError, got %T", err)
+				}
+				want := []FieldError{
+					{Field: "end", Message: "is in the future"},
+					{Field: "end", Message: "is invalid"},
+					{Field: "start", Message: "must be before end"},
+				}
+				if vErr.Message != "validation failed" || !slices.Equal(vErr.Fields, want) {
+					t.Errorf("unexpected validation error %+v", vErr)
+				}
+			},
+		},
+		{
+			name:       "400 Bad Request (plain body)",
+			statusCode: http.StatusBadRequest,
+			body:       "bad request",
+			wantErr: func(t *testing.T, err error) {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Message != "bad request" || len(vErr.Fields) != 0 {
+					t.Errorf("expected the raw body as the message, got %+v", vErr)
+				}
+			},
+		},
+		{
+			name:       "request and trace IDs",
+			statusCode: http.StatusForbidden,
+			header: http.Header{
+				"X-Request-Id":    []string{"req-123"},
+				"X-Amzn-Trace-Id": []string{"Root=1-abc"},
+			},
+			body: "forbidden",
+			wantErr: func(t *testing.T, err error) {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expected APIError, got %T", err)
+				}
+				if apiErr.RequestID != "req-123" || apiErr.TraceID != "Root=1-abc" {
+					t.Errorf("unexpected IDs %q and %q", apiErr.RequestID, apiErr.TraceID)
+				}
+				if apiErr.Header.Get("X-Request-Id") != "req-123" {
+					t.Error("expected the response headers to be kept")
+				}
+				if !strings.Contains(err.Error(), "request id req-123") {
+					t.Errorf("expected the request ID in the message, got %s", err)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
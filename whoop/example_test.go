@@ -119,6 +119,23 @@ func ExampleWorkoutService_List() {
 	}
 }
 
+// List only cardio workouts using the client-side sport filter.
+func ExampleWorkoutService_ListFiltered() {
+	client := whoop.NewClient(whoop.WithToken("your_token"))
+	ctx := context.Background()
+
+	filter := whoop.WorkoutFilter{Categories: []whoop.SportCategory{whoop.SportCategoryCardio}}
+	page, err := client.Workout.ListFiltered(ctx, &whoop.ListOptions{Limit: 25}, filter)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	for _, w := range page.Records {
+		fmt.Printf("Workout %s: %s (%s)\n", w.ID, w.Sport().Name, w.Sport().Category)
+	}
+}
+
 // Fetch a single workout by its UUID.
 func ExampleWorkoutService_GetByID() {
 	client := whoop.NewClient(whoop.WithToken("your_token"))
@@ -134,6 +151,42 @@ func ExampleWorkoutService_GetByID() {
 	}
 }
 
+// Fetch a backlog of workouts by ID with bounded concurrency.
+func ExampleWorkoutService_GetMany() {
+	client := whoop.NewClient(
+		whoop.WithToken("your_token"),
+		whoop.WithBatchConcurrency(8),
+	)
+
+	res := client.Workout.GetMany(context.Background(), []string{"abc-def-123", "abc-def-456"})
+	for id, w := range res.Records {
+		fmt.Printf("Workout %s: %s\n", id, w.SportName)
+	}
+	for id, err := range res.Errors {
+		fmt.Printf("Workout %s failed: %v\n", id, err)
+	}
+}
+
+// Wait for a workout announced by a webhook to finish scoring.
+func ExampleWorkoutService_WaitForScore() {
+	client := whoop.NewClient(whoop.WithToken("your_token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	workout, err := client.Workout.WaitForScore(ctx, "abc-def-123", 10*time.Second)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	if workout.IsScored() {
+		fmt.Printf("Workout %s: Strain=%.1f\n", workout.ID, workout.Score.Strain)
+	} else {
+		fmt.Printf("Workout %s could not be scored (%s)\n", workout.ID, workout.ScoreState)
+	}
+}
+
 // Iterate through sleep events with pagination.
 func ExampleSleepService_List() {
 	client := whoop.NewClient(whoop.WithToken("your_token"))
@@ -166,8 +219,8 @@ func ExampleSleepService_GetByID() {
 
 	fmt.Printf("Sleep %s: Nap=%v\n", sleep.ID, sleep.Nap)
 	if sleep.Score != nil && sleep.Score.StageSummary != nil {
-		rem := time.Duration(sleep.Score.StageSummary.TotalRemSleepTimeMilli) * time.Millisecond
-		fmt.Printf("  REM sleep: %s\n", rem)
+		fmt.Printf("  REM sleep: %s\n", sleep.Score.StageSummary.TotalRemSleepTime())
+		fmt.Printf("  Total sleep: %s\n", sleep.Score.StageSummary.TotalSleepTime())
 	}
 }
 
@@ -209,6 +262,29 @@ func ExampleRecoveryService_GetByID() {
 	}
 }
 
+// Build one record per physiological cycle for a dashboard.
+func ExampleClient_Days() {
+	client := whoop.NewClient(whoop.WithToken("your_token"))
+
+	end := time.Now()
+	days, err := client.Days(context.Background(), end.AddDate(0, 0, -7), end)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	for _, day := range days {
+		date, _ := day.LocalDate()
+		fmt.Printf("%s: %d workouts, %d naps\n", date, len(day.Workouts), len(day.Naps))
+		if day.Recovery != nil && day.Recovery.IsScored() {
+			fmt.Printf("  Recovery: %.0f%%\n", day.Recovery.Score.RecoveryScore)
+		}
+		if !day.IsComplete() {
+			fmt.Println("  (some data is missing or still being scored)")
+		}
+	}
+}
+
 // Securely verify and parse incoming WHOOP webhook payloads.
 func ExampleParseWebhook() {
 	http.HandleFunc("/whoop/webhook", func(w http.ResponseWriter, r *http.Request) {
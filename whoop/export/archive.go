@@ -0,0 +1,342 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// ArchiveSchemaVersion is the version of the archive format written by
+// ArchiveWriter. Readers reject archives with a newer version.
+const ArchiveSchemaVersion = 1
+
+// ErrUnsupportedArchiveVersion is returned by NewArchiveReader when the archive
+// was written with a newer schema version than this package understands.
+var ErrUnsupportedArchiveVersion = errors.New("unsupported archive schema version")
+
+// ErrMissingArchiveHeader is returned by NewArchiveReader when the first line
+// of the input is not an archive header.
+var ErrMissingArchiveHeader = errors.New("missing archive header")
+
+// RecordType identifies the resource type of an archived record.
+type RecordType string
+
+// Record types written by ArchiveWriter.
+const (
+	RecordCycle           RecordType = "cycle"
+	RecordSleep           RecordType = "sleep"
+	RecordWorkout         RecordType = "workout"
+	RecordRecovery        RecordType = "recovery"
+	RecordProfile         RecordType = "profile"
+	RecordBodyMeasurement RecordType = "body_measurement"
+)
+
+// ArchiveHeader is the first line of an archive.
+type ArchiveHeader struct {
+	SchemaVersion int `json:"schema_version"`
+
+	// UserID is the WHOOP user whose records the archive holds.
+	UserID int `json:"user_id,omitempty"`
+
+	// Start and End bound the time range that was exported, if any.
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+
+	// CreatedAt is when the archive was written.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ArchiveRecord is one line of an archive after the header. Data holds the
+// record's JSON exactly as it was written, including fields the model types
+// do not define.
+type ArchiveRecord struct {
+	Type RecordType      `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Cycle decodes the record as a cycle.
+func (r ArchiveRecord) Cycle() (*whoop.Cycle, error) {
+	return decodeRecord[whoop.Cycle](r, RecordCycle)
+}
+
+// Sleep decodes the record as a sleep.
+func (r ArchiveRecord) Sleep() (*whoop.Sleep, error) {
+	return decodeRecord[whoop.Sleep](r, RecordSleep)
+}
+
+// Workout decodes the record as a workout.
+func (r ArchiveRecord) Workout() (*whoop.Workout, error) {
+	return decodeRecord[whoop.Workout](r, RecordWorkout)
+}
+
+// Recovery decodes the record as a recovery.
+func (r ArchiveRecord) Recovery() (*whoop.Recovery, error) {
+	return decodeRecord[whoop.Recovery](r, RecordRecovery)
+}
+
+// Profile decodes the record as a basic profile.
+func (r ArchiveRecord) Profile() (*whoop.BasicProfile, error) {
+	return decodeRecord[whoop.BasicProfile](r, RecordProfile)
+}
+
+// BodyMeasurement decodes the record as a body measurement.
+func (r ArchiveRecord) BodyMeasurement() (*whoop.BodyMeasurement, error) {
+	return decodeRecord[whoop.BodyMeasurement](r, RecordBodyMeasurement)
+}
+
+func decodeRecord[T any](r ArchiveRecord, want RecordType) (*T, error) {
+	if r.Type != want {
+		return nil, fmt.Errorf("archive record is %q, not %q", r.Type, want)
+	}
+	var v T
+	if err := json.Unmarshal(r.Data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s record: %w", r.Type, err)
+	}
+	return &v, nil
+}
+
+// ArchiveWriter streams records as newline-delimited JSON. The header line is
+// written before the first record, or by Flush if no records were written. An
+// ArchiveWriter is not safe for concurrent use.
+type ArchiveWriter struct {
+	w           *bufio.Writer
+	header      ArchiveHeader
+	wroteHeader bool
+}
+
+// NewArchiveWriter returns an ArchiveWriter that writes to w. The header's
+// SchemaVersion is always set to ArchiveSchemaVersion, and a zero CreatedAt
+// is set to the current time.
+func NewArchiveWriter(w io.Writer, header ArchiveHeader) *ArchiveWriter {
+	header.SchemaVersion = ArchiveSchemaVersion
+	if header.CreatedAt.IsZero() {
+		header.CreatedAt = time.Now().UTC()
+	}
+	return &ArchiveWriter{w: bufio.NewWriter(w), header: header}
+}
+
+// WriteRaw writes a record whose data is already JSON, such as a response body
+// from the API. The data is compacted onto one line but otherwise kept as is.
+func (a *ArchiveWriter) WriteRaw(typ RecordType, data json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return fmt.Errorf("invalid %s record: %w", typ, err)
+	}
+	return a.writeLine(ArchiveRecord{Type: typ, Data: buf.Bytes()})
+}
+
+// WriteCycle writes a cycle record.
+func (a *ArchiveWriter) WriteCycle(c *whoop.Cycle) error { return a.writeValue(RecordCycle, c) }
+
+// WriteSleep writes a sleep record.
+func (a *ArchiveWriter) WriteSleep(s *whoop.Sleep) error { return a.writeValue(RecordSleep, s) }
+
+// WriteWorkout writes a workout record.
+func (a *ArchiveWriter) WriteWorkout(w *whoop.Workout) error { return a.writeValue(RecordWorkout, w) }
+
+// WriteRecovery writes a recovery record.
+func (a *ArchiveWriter) WriteRecovery(r *whoop.Recovery) error {
+	return a.writeValue(RecordRecovery, r)
+}
+
+// WriteProfile writes a basic profile record.
+func (a *ArchiveWriter) WriteProfile(p *whoop.BasicProfile) error {
+	return a.writeValue(RecordProfile, p)
+}
+
+// WriteBodyMeasurement writes a body measurement record.
+func (a *ArchiveWriter) WriteBodyMeasurement(m *whoop.BodyMeasurement) error {
+	return a.writeValue(RecordBodyMeasurement, m)
+}
+
+// Flush writes the header line if no records were written, then flushes any
+// buffered lines to the underlying writer.
+func (a *ArchiveWriter) Flush() error {
+	if err := a.writeHeader(); err != nil {
+		return err
+	}
+	return a.w.Flush()
+}
+
+func (a *ArchiveWriter) writeValue(typ RecordType, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", typ, err)
+	}
+	return a.writeLine(ArchiveRecord{Type: typ, Data: data})
+}
+
+func (a *ArchiveWriter) writeHeader() error {
+	if a.wroteHeader {
+		return nil
+	}
+	a.wroteHeader = true
+	return a.encode(a.header)
+}
+
+func (a *ArchiveWriter) writeLine(rec ArchiveRecord) error {
+	if err := a.writeHeader(); err != nil {
+		return err
+	}
+	return a.encode(rec)
+}
+
+func (a *ArchiveWriter) encode(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := a.w.Write(data); err != nil {
+		return err
+	}
+	return a.w.WriteByte('\n')
+}
+
+// ArchiveReader reads records from an archive written by ArchiveWriter.
+type ArchiveReader struct {
+	// Header is the archive's header line.
+	Header ArchiveHeader
+
+	dec *json.Decoder
+}
+
+// NewArchiveReader reads the header line from r and returns a reader
+// positioned at the first record.
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	dec := json.NewDecoder(r)
+
+	var header ArchiveHeader
+	if err := dec.Decode(&header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMissingArchiveHeader
+		}
+		return nil, fmt.Errorf("failed to read archive header: %w", err)
+	}
+	if header.SchemaVersion == 0 {
+		return nil, ErrMissingArchiveHeader
+	}
+	if header.SchemaVersion > ArchiveSchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, header.SchemaVersion)
+	}
+	return &ArchiveReader{Header: header, dec: dec}, nil
+}
+
+// Next returns the next record, or io.EOF when the archive is exhausted.
+// Records of types this package does not know are returned as is, so newer
+// archives can still be read.
+func (r *ArchiveReader) Next() (ArchiveRecord, error) {
+	var rec ArchiveRecord
+	if err := r.dec.Decode(&rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ArchiveRecord{}, io.EOF
+		}
+		return ArchiveRecord{}, fmt.Errorf("failed to read archive record: %w", err)
+	}
+	return rec, nil
+}
+
+// collections lists the paginated resources archived by ArchiveAll, in order.
+var collections = []struct {
+	typ  RecordType
+	path string
+}{
+	{RecordCycle, whoop.CyclePath},
+	{RecordSleep, whoop.SleepPath},
+	{RecordWorkout, whoop.WorkoutPath},
+	{RecordRecovery, whoop.RecoveryPath},
+}
+
+// ArchiveAll writes the user's profile, body measurement, and every cycle,
+// sleep, workout and recovery in the range selected by opts to a, then
+// flushes it. Header fields the caller left zero are filled in before the
+// first line is written: UserID from the profile, and Start and End from
+// opts. Records are copied from the API responses without decoding, so
+// fields the model types do not define are preserved. A client configured
+// with whoop.WithScopes fails with a *whoop.MissingScopeError before any
+// request unless every resource's scope was granted.
+func ArchiveAll(ctx context.Context, client *whoop.Client, a *ArchiveWriter, opts *whoop.ListOptions) error {
+	if err := client.CheckScopes(
+		whoop.ServiceProfile, whoop.ServiceBodyMeasurement,
+		whoop.ServiceCycle, whoop.ServiceSleep, whoop.ServiceWorkout, whoop.ServiceRecovery,
+	); err != nil {
+		return err
+	}
+	for _, single := range []struct {
+		typ  RecordType
+		path string
+	}{
+		{RecordProfile, whoop.BasicProfilePath},
+		{RecordBodyMeasurement, whoop.BodyMeasurementPath},
+	} {
+		var raw json.RawMessage
+		if err := client.Get(ctx, single.path, &raw); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", single.typ, err)
+		}
+		if single.typ == RecordProfile {
+			if err := a.fillHeader(raw, opts); err != nil {
+				return err
+			}
+		}
+		if err := a.WriteRaw(single.typ, raw); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range collections {
+		if err := archiveCollection(ctx, client, a, c.typ, c.path, opts); err != nil {
+			return err
+		}
+	}
+	return a.Flush()
+}
+
+// fillHeader sets the header's zero UserID from the profile's user_id and
+// its nil Start and End from opts. It does nothing once the header has been
+// written.
+func (a *ArchiveWriter) fillHeader(profile json.RawMessage, opts *whoop.ListOptions) error {
+	if a.wroteHeader {
+		return nil
+	}
+	if a.header.UserID == 0 {
+		var p struct {
+			UserID int `json:"user_id"`
+		}
+		if err := json.Unmarshal(profile, &p); err != nil {
+			return fmt.Errorf("invalid %s record: %w", RecordProfile, err)
+		}
+		a.header.UserID = p.UserID
+	}
+	if opts == nil {
+		return nil
+	}
+	if a.header.Start == nil && opts.Start != nil {
+		start := *opts.Start
+		a.header.Start = &start
+	}
+	if a.header.End == nil && opts.End != nil {
+		end := *opts.End
+		a.header.End = &end
+	}
+	return nil
+}
+
+// archiveCollection writes every page of the collection at path to a.
+func archiveCollection(ctx context.Context, client *whoop.Client, a *ArchiveWriter, typ RecordType, path string, opts *whoop.ListOptions) error {
+	page, err := client.ListRaw(ctx, path, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list %s records: %w", typ, err)
+	}
+	err = eachRecord(ctx, page, func(p *whoop.RawPage) []json.RawMessage { return p.Records }, (*whoop.RawPage).NextPage,
+		func(raw *json.RawMessage) error { return a.WriteRaw(typ, *raw) })
+	if err != nil {
+		return fmt.Errorf("failed to archive %s records: %w", typ, err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cycle := whoop.Cycle{
+		ID:             123,
+		UserID:         999,
+		Start:          start,
+		TimezoneOffset: "-08:00",
+		ScoreState:     whoop.ScoreStateScored,
+		Score:          &whoop.Score{Strain: 12.4},
+	}
+	recovery := whoop.Recovery{CycleID: 123, SleepID: "slp", ScoreState: whoop.ScoreStatePendingScore}
+
+	var buf bytes.Buffer
+	a := NewArchiveWriter(&buf, ArchiveHeader{UserID: 999, Start: &start, CreatedAt: created})
+	if err := a.WriteCycle(&cycle); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.WriteRecovery(&recovery); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("expected a header and two record lines, got %d:\n%s", lines, buf.String())
+	}
+
+	r, err := NewArchiveReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h := r.Header
+	if h.SchemaVersion != ArchiveSchemaVersion || h.UserID != 999 || !h.Start.Equal(start) || h.End != nil || !h.CreatedAt.Equal(created) {
+		t.Errorf("unexpected header: %+v", h)
+	}
+
+	rec, err := r.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := rec.Cycle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 123 || !got.Start.Equal(start) || got.Score.Strain != 12.4 {
+		t.Errorf("unexpected cycle: %+v", got)
+	}
+	if _, err := rec.Sleep(); err == nil {
+		t.Error("expected an error decoding a cycle record as a sleep")
+	}
+
+	rec, err = r.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotRec, err := rec.Recovery(); err != nil || gotRec.SleepID != "slp" || gotRec.Score != nil {
+		t.Errorf("unexpected recovery %+v (%v)", gotRec, err)
+	}
+
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestArchiveReader_Header(t *testing.T) {
+	if _, err := NewArchiveReader(strings.NewReader("")); !errors.Is(err, ErrMissingArchiveHeader) {
+		t.Errorf("expected ErrMissingArchiveHeader for empty input, got %v", err)
+	}
+	if _, err := NewArchiveReader(strings.NewReader(`{"type":"cycle","data":{}}` + "\n")); !errors.Is(err, ErrMissingArchiveHeader) {
+		t.Errorf("expected ErrMissingArchiveHeader for a record line, got %v", err)
+	}
+	if _, err := NewArchiveReader(strings.NewReader(`{"schema_version":99}` + "\n")); !errors.Is(err, ErrUnsupportedArchiveVersion) {
+		t.Errorf("expected ErrUnsupportedArchiveVersion, got %v", err)
+	}
+
+	// An empty archive still has a header line.
+	var buf bytes.Buffer
+	if err := NewArchiveWriter(&buf, ArchiveHeader{}).Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := NewArchiveReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Header.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to default to the current time")
+	}
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestArchiveAll_PreservesUnknownFields(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/profile/basic", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user_id": 999, "email": "a@example.com", "first_name": "A", "last_name": "B"}`))
+	})
+	mux.HandleFunc("/user/measurement/body", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"height_meter": 1.8, "weight_kilogram": 80, "max_heart_rate": 195}`))
+	})
+	mux.HandleFunc("/cycle", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "10" {
+			t.Errorf("expected list options to be forwarded, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("nextToken") == "" {
+			_, _ = w.Write([]byte(`{"records": [{"id": 1, "future_field": {"nested": [1, 2]}}], "next_token": "page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records": [{"id": 2}], "next_token": ""}`))
+	})
+	for _, path := range []string{"/activity/sleep", "/activity/workout", "/recovery"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"records": [], "next_token": ""}`))
+		})
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := whoop.NewClient(whoop.WithBaseURL(ts.URL), whoop.WithRateLimiting(false))
+	var buf bytes.Buffer
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewArchiveWriter(&buf, ArchiveHeader{})
+	if err := ArchiveAll(context.Background(), client, a, &whoop.ListOptions{Limit: 10, Start: &start}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := NewArchiveReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Header.UserID != 999 || r.Header.Start == nil || !r.Header.Start.Equal(start) || r.Header.End != nil {
+		t.Errorf("expected the header to be filled from the profile and options, got %+v", r.Header)
+	}
+	var types []string
+	var first ArchiveRecord
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rec.Type == RecordCycle && first.Type == "" {
+			first = rec
+		}
+		types = append(types, string(rec.Type))
+	}
+
+	if got := strings.Join(types, ","); got != "profile,body_measurement,cycle,cycle" {
+		t.Errorf("unexpected record types %s", got)
+	}
+	if string(first.Data) != `{"id":1,"future_field":{"nested":[1,2]}}` {
+		t.Errorf("expected raw data to be preserved, got %s", first.Data)
+	}
+}
+
+func TestArchiveAll_MissingScope(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := whoop.NewClient(
+		whoop.WithBaseURL(ts.URL),
+		whoop.WithRateLimiting(false),
+		whoop.WithScopes(whoop.ScopeReadProfile, whoop.ScopeReadBodyMeasurement, whoop.ScopeReadCycles),
+	)
+	var buf bytes.Buffer
+	err := ArchiveAll(context.Background(), client, NewArchiveWriter(&buf, ArchiveHeader{}), nil)
+	var scopeErr *whoop.MissingScopeError
+	if !errors.As(err, &scopeErr) || scopeErr.Scope != whoop.ScopeReadRecovery {
+		t.Errorf("expected a MissingScopeError for read:recovery, got %v", err)
+	}
+	if requests != 0 || buf.Len() != 0 {
+		t.Errorf("expected no requests or output, got %d requests and %q", requests, buf.String())
+	}
+}
@@ -0,0 +1,381 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// localTimeLayout formats local-time columns without an offset, which
+// spreadsheet applications parse as a plain date and time.
+const localTimeLayout = "2006-01-02 15:04:05"
+
+// CSVOptions configures the CSV writers. The zero value writes UTC timestamps
+// only, in the units reported by the API.
+type CSVOptions struct {
+	// LocalTime adds start_local, end_local and local_date columns in the
+	// record's own timezone, derived from its TimezoneOffset. It has no effect
+	// on recoveries, which carry no timezone. Records with an invalid offset
+	// leave these columns empty.
+	LocalTime bool
+
+	Energy      EnergyUnit
+	Distance    DistanceUnit
+	Temperature TemperatureUnit
+	Duration    DurationUnit
+}
+
+// column is a named CSV column and the function that renders its value.
+type column[T any] struct {
+	name  string
+	value func(*T) string
+}
+
+// Writer streams records of one type as CSV rows. The header row is written
+// before the first record, or by Flush if no records were written. A Writer
+// is not safe for concurrent use.
+type Writer[T any] struct {
+	csv         *csv.Writer
+	columns     []column[T]
+	wroteHeader bool
+}
+
+func newWriter[T any](w io.Writer, columns []column[T]) *Writer[T] {
+	return &Writer[T]{csv: csv.NewWriter(w), columns: columns}
+}
+
+// Columns returns the header row.
+func (w *Writer[T]) Columns() []string {
+	names := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Write writes one record, preceded by the header row if it has not been
+// written yet. Rows are buffered; call Flush when done.
+func (w *Writer[T]) Write(record *T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	row := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		row[i] = c.value(record)
+	}
+	return w.csv.Write(row)
+}
+
+// WriteAll writes each of records.
+func (w *Writer[T]) WriteAll(records []T) error {
+	for i := range records {
+		if err := w.Write(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes the header row if no records were written, then flushes any
+// buffered rows to the underlying writer.
+func (w *Writer[T]) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *Writer[T]) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.csv.Write(w.Columns())
+}
+
+// writePages writes the records of page and every page after it, then flushes.
+func writePages[T, P any](ctx context.Context, w *Writer[T], page *P, records func(*P) []T, next func(*P, context.Context) (*P, error)) error {
+	if err := eachRecord(ctx, page, records, next, w.Write); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// eachRecord calls fn for every record of page and the pages after it,
+// stopping at the first error.
+func eachRecord[T, P any](ctx context.Context, page *P, records func(*P) []T, next func(*P, context.Context) (*P, error), fn func(*T) error) error {
+	for {
+		rs := records(page)
+		for i := range rs {
+			if err := fn(&rs[i]); err != nil {
+				return err
+			}
+		}
+		var err error
+		page, err = next(page, ctx)
+		if errors.Is(err, whoop.ErrNoNextPage) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// NewCycleWriter returns a Writer for cycles.
+func NewCycleWriter(w io.Writer, opts CSVOptions) *Writer[whoop.Cycle] {
+	score := func(c *whoop.Cycle) *whoop.Score { return c.Score }
+
+	cols := []column[whoop.Cycle]{
+		{"id", func(c *whoop.Cycle) string { return strconv.Itoa(c.ID) }},
+		{"user_id", func(c *whoop.Cycle) string { return strconv.Itoa(c.UserID) }},
+		{"created_at", func(c *whoop.Cycle) string { return formatTime(c.CreatedAt) }},
+		{"updated_at", func(c *whoop.Cycle) string { return formatTime(c.UpdatedAt) }},
+		{"start", func(c *whoop.Cycle) string { return formatTime(c.Start) }},
+		{"end", func(c *whoop.Cycle) string {
+			if c.End == nil {
+				return ""
+			}
+			return formatTime(*c.End)
+		}},
+		{"timezone_offset", func(c *whoop.Cycle) string { return c.TimezoneOffset }},
+	}
+	if opts.LocalTime {
+		cols = append(cols,
+			column[whoop.Cycle]{"start_local", func(c *whoop.Cycle) string { return formatLocal(c.LocalStart()) }},
+			column[whoop.Cycle]{"end_local", func(c *whoop.Cycle) string {
+				end, err := c.LocalEnd()
+				if err != nil || end == nil {
+					return ""
+				}
+				return end.Format(localTimeLayout)
+			}},
+			column[whoop.Cycle]{"local_date", func(c *whoop.Cycle) string { return formatDate(c.LocalDate()) }},
+		)
+	}
+	cols = append(cols,
+		column[whoop.Cycle]{"score_state", func(c *whoop.Cycle) string { return string(c.ScoreState) }},
+		scored("strain", score, func(s *whoop.Score) string { return formatFloat(s.Strain) }),
+		scored("energy_"+opts.Energy.suffix(), score, func(s *whoop.Score) string { return formatFloat(opts.Energy.convert(s.Energy())) }),
+		scored("average_heart_rate", score, func(s *whoop.Score) string { return strconv.Itoa(s.AverageHeartRate) }),
+		scored("max_heart_rate", score, func(s *whoop.Score) string { return strconv.Itoa(s.MaxHeartRate) }),
+	)
+	return newWriter(w, cols)
+}
+
+// WriteCyclePages writes page and every following page to w, then flushes it.
+func WriteCyclePages(ctx context.Context, w *Writer[whoop.Cycle], page *whoop.CyclePage) error {
+	return writePages(ctx, w, page, func(p *whoop.CyclePage) []whoop.Cycle { return p.Records }, (*whoop.CyclePage).NextPage)
+}
+
+// NewSleepWriter returns a Writer for sleeps, including the stage summary and
+// sleep need breakdown.
+func NewSleepWriter(w io.Writer, opts CSVOptions) *Writer[whoop.Sleep] {
+	score := func(s *whoop.Sleep) *whoop.SleepScore { return s.Score }
+	stages := func(s *whoop.Sleep) *whoop.StageSummary {
+		if s.Score == nil {
+			return nil
+		}
+		return s.Score.StageSummary
+	}
+	need := func(s *whoop.Sleep) *whoop.SleepNeeded {
+		if s.Score == nil {
+			return nil
+		}
+		return s.Score.SleepNeeded
+	}
+	dur := func(name string) string { return name + "_" + opts.Duration.suffix() }
+	fmtDur := func(d time.Duration) string { return formatFloat(opts.Duration.convert(d)) }
+
+	cols := []column[whoop.Sleep]{
+		{"id", func(s *whoop.Sleep) string { return s.ID }},
+		{"cycle_id", func(s *whoop.Sleep) string { return strconv.Itoa(s.CycleID) }},
+		{"v1_id", func(s *whoop.Sleep) string { return formatIntPtr(s.V1ID) }},
+		{"user_id", func(s *whoop.Sleep) string { return strconv.Itoa(s.UserID) }},
+		{"created_at", func(s *whoop.Sleep) string { return formatTime(s.CreatedAt) }},
+		{"updated_at", func(s *whoop.Sleep) string { return formatTime(s.UpdatedAt) }},
+		{"start", func(s *whoop.Sleep) string { return formatTime(s.Start) }},
+		{"end", func(s *whoop.Sleep) string { return formatTime(s.End) }},
+		{"timezone_offset", func(s *whoop.Sleep) string { return s.TimezoneOffset }},
+	}
+	if opts.LocalTime {
+		cols = append(cols,
+			column[whoop.Sleep]{"start_local", func(s *whoop.Sleep) string { return formatLocal(s.LocalStart()) }},
+			column[whoop.Sleep]{"end_local", func(s *whoop.Sleep) string { return formatLocal(s.LocalEnd()) }},
+			column[whoop.Sleep]{"local_date", func(s *whoop.Sleep) string { return formatDate(s.LocalDate()) }},
+		)
+	}
+	cols = append(cols,
+		column[whoop.Sleep]{"nap", func(s *whoop.Sleep) string { return strconv.FormatBool(s.Nap) }},
+		column[whoop.Sleep]{"score_state", func(s *whoop.Sleep) string { return string(s.ScoreState) }},
+		scored("respiratory_rate", score, func(s *whoop.SleepScore) string { return formatFloat(s.RespiratoryRate) }),
+		scored("sleep_performance_percentage", score, func(s *whoop.SleepScore) string { return formatFloat(s.SleepPerformancePercentage) }),
+		scored("sleep_consistency_percentage", score, func(s *whoop.SleepScore) string { return formatFloat(s.SleepConsistencyPercentage) }),
+		scored("sleep_efficiency_percentage", score, func(s *whoop.SleepScore) string { return formatFloat(s.SleepEfficiencyPercentage) }),
+		scored(dur("total_in_bed_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalInBedTime()) }),
+		scored(dur("total_awake_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalAwakeTime()) }),
+		scored(dur("total_no_data_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalNoDataTime()) }),
+		scored(dur("total_light_sleep_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalLightSleepTime()) }),
+		scored(dur("total_slow_wave_sleep_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalSlowWaveSleepTime()) }),
+		scored(dur("total_rem_sleep_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalRemSleepTime()) }),
+		scored(dur("total_sleep_time"), stages, func(s *whoop.StageSummary) string { return fmtDur(s.TotalSleepTime()) }),
+		scored("sleep_cycle_count", stages, func(s *whoop.StageSummary) string { return strconv.Itoa(s.SleepCycleCount) }),
+		scored("disturbance_count", stages, func(s *whoop.StageSummary) string { return strconv.Itoa(s.DisturbanceCount) }),
+		scored(dur("sleep_need_baseline"), need, func(n *whoop.SleepNeeded) string { return fmtDur(n.Baseline()) }),
+		scored(dur("sleep_need_from_sleep_debt"), need, func(n *whoop.SleepNeeded) string { return fmtDur(n.NeedFromSleepDebt()) }),
+		scored(dur("sleep_need_from_recent_strain"), need, func(n *whoop.SleepNeeded) string { return fmtDur(n.NeedFromRecentStrain()) }),
+		scored(dur("sleep_need_from_recent_nap"), need, func(n *whoop.SleepNeeded) string { return fmtDur(n.NeedFromRecentNap()) }),
+		scored(dur("sleep_need_total"), need, func(n *whoop.SleepNeeded) string { return fmtDur(n.Total()) }),
+	)
+	return newWriter(w, cols)
+}
+
+// WriteSleepPages writes page and every following page to w, then flushes it.
+func WriteSleepPages(ctx context.Context, w *Writer[whoop.Sleep], page *whoop.SleepPage) error {
+	return writePages(ctx, w, page, func(p *whoop.SleepPage) []whoop.Sleep { return p.Records }, (*whoop.SleepPage).NextPage)
+}
+
+// NewWorkoutWriter returns a Writer for workouts, including the time spent in
+// each heart rate zone.
+func NewWorkoutWriter(w io.Writer, opts CSVOptions) *Writer[whoop.Workout] {
+	score := func(w *whoop.Workout) *whoop.WorkoutScore { return w.Score }
+	zones := func(w *whoop.Workout) *whoop.ZoneDurations {
+		if w.Score == nil {
+			return nil
+		}
+		return w.Score.ZoneDuration
+	}
+	dist := func(name string) string { return name + "_" + opts.Distance.suffix() }
+	fmtDist := func(d whoop.Distance, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return formatFloat(opts.Distance.convert(d))
+	}
+	dur := func(name string) string { return name + "_" + opts.Duration.suffix() }
+	fmtDur := func(d time.Duration) string { return formatFloat(opts.Duration.convert(d)) }
+
+	cols := []column[whoop.Workout]{
+		{"id", func(w *whoop.Workout) string { return w.ID }},
+		{"v1_id", func(w *whoop.Workout) string { return formatIntPtr(w.V1ID) }},
+		{"user_id", func(w *whoop.Workout) string { return strconv.Itoa(w.UserID) }},
+		{"created_at", func(w *whoop.Workout) string { return formatTime(w.CreatedAt) }},
+		{"updated_at", func(w *whoop.Workout) string { return formatTime(w.UpdatedAt) }},
+		{"start", func(w *whoop.Workout) string { return formatTime(w.Start) }},
+		{"end", func(w *whoop.Workout) string { return formatTime(w.End) }},
+		{"timezone_offset", func(w *whoop.Workout) string { return w.TimezoneOffset }},
+	}
+	if opts.LocalTime {
+		cols = append(cols,
+			column[whoop.Workout]{"start_local", func(w *whoop.Workout) string { return formatLocal(w.LocalStart()) }},
+			column[whoop.Workout]{"end_local", func(w *whoop.Workout) string { return formatLocal(w.LocalEnd()) }},
+			column[whoop.Workout]{"local_date", func(w *whoop.Workout) string { return formatDate(w.LocalDate()) }},
+		)
+	}
+	cols = append(cols,
+		column[whoop.Workout]{"sport_id", func(w *whoop.Workout) string { return strconv.Itoa(w.SportID) }},
+		column[whoop.Workout]{"sport_name", func(w *whoop.Workout) string { return w.Sport().Name }},
+		column[whoop.Workout]{dur("duration"), func(w *whoop.Workout) string { return fmtDur(w.Duration()) }},
+		column[whoop.Workout]{"score_state", func(w *whoop.Workout) string { return string(w.ScoreState) }},
+		scored("strain", score, func(s *whoop.WorkoutScore) string { return formatFloat(s.Strain) }),
+		scored("average_heart_rate", score, func(s *whoop.WorkoutScore) string { return strconv.Itoa(s.AverageHeartRate) }),
+		scored("max_heart_rate", score, func(s *whoop.WorkoutScore) string { return strconv.Itoa(s.MaxHeartRate) }),
+		scored("energy_"+opts.Energy.suffix(), score, func(s *whoop.WorkoutScore) string { return formatFloat(opts.Energy.convert(s.Energy())) }),
+		scored("percent_recorded", score, func(s *whoop.WorkoutScore) string { return formatFloat(s.PercentRecorded) }),
+		scored(dist("distance"), score, func(s *whoop.WorkoutScore) string { return fmtDist(s.Distance()) }),
+		scored(dist("altitude_gain"), score, func(s *whoop.WorkoutScore) string { return fmtDist(s.AltitudeGain()) }),
+		scored(dist("altitude_change"), score, func(s *whoop.WorkoutScore) string { return fmtDist(s.AltitudeChange()) }),
+		scored(dur("zone_zero"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneZero()) }),
+		scored(dur("zone_one"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneOne()) }),
+		scored(dur("zone_two"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneTwo()) }),
+		scored(dur("zone_three"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneThree()) }),
+		scored(dur("zone_four"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneFour()) }),
+		scored(dur("zone_five"), zones, func(z *whoop.ZoneDurations) string { return fmtDur(z.ZoneFive()) }),
+	)
+	return newWriter(w, cols)
+}
+
+// WriteWorkoutPages writes page and every following page to w, then flushes it.
+// Filtered pages from WorkoutService.ListFiltered keep their filter.
+func WriteWorkoutPages(ctx context.Context, w *Writer[whoop.Workout], page *whoop.WorkoutPage) error {
+	return writePages(ctx, w, page, func(p *whoop.WorkoutPage) []whoop.Workout { return p.Records }, (*whoop.WorkoutPage).NextPage)
+}
+
+// NewRecoveryWriter returns a Writer for recoveries. Recoveries carry no
+// timezone, so CSVOptions.LocalTime does not apply.
+func NewRecoveryWriter(w io.Writer, opts CSVOptions) *Writer[whoop.Recovery] {
+	score := func(r *whoop.Recovery) *whoop.RecoveryScore { return r.Score }
+
+	cols := []column[whoop.Recovery]{
+		{"cycle_id", func(r *whoop.Recovery) string { return strconv.Itoa(r.CycleID) }},
+		{"sleep_id", func(r *whoop.Recovery) string { return r.SleepID }},
+		{"user_id", func(r *whoop.Recovery) string { return strconv.Itoa(r.UserID) }},
+		{"created_at", func(r *whoop.Recovery) string { return formatTime(r.CreatedAt) }},
+		{"updated_at", func(r *whoop.Recovery) string { return formatTime(r.UpdatedAt) }},
+		{"score_state", func(r *whoop.Recovery) string { return string(r.ScoreState) }},
+		scored("user_calibrating", score, func(s *whoop.RecoveryScore) string { return strconv.FormatBool(s.UserCalibrating) }),
+		scored("recovery_score", score, func(s *whoop.RecoveryScore) string { return formatFloat(s.RecoveryScore) }),
+		scored("resting_heart_rate", score, func(s *whoop.RecoveryScore) string { return formatFloat(s.RestingHeartRate) }),
+		scored("hrv_rmssd_milli", score, func(s *whoop.RecoveryScore) string { return formatFloat(s.HrvRmssdMilli) }),
+		scored("spo2_percentage", score, func(s *whoop.RecoveryScore) string { return formatFloat(s.Spo2Percentage) }),
+		scored("skin_temp_"+opts.Temperature.suffix(), score, func(s *whoop.RecoveryScore) string {
+			return formatFloat(opts.Temperature.convert(s.SkinTemp()))
+		}),
+	}
+	return newWriter(w, cols)
+}
+
+// WriteRecoveryPages writes page and every following page to w, then flushes it.
+func WriteRecoveryPages(ctx context.Context, w *Writer[whoop.Recovery], page *whoop.RecoveryPage) error {
+	return writePages(ctx, w, page, func(p *whoop.RecoveryPage) []whoop.Recovery { return p.Records }, (*whoop.RecoveryPage).NextPage)
+}
+
+// scored builds a column over a nested value of T, such as its score, that is
+// left empty when the nested value is nil.
+func scored[T, S any](name string, get func(*T) *S, value func(*S) string) column[T] {
+	return column[T]{name, func(record *T) string {
+		s := get(record)
+		if s == nil {
+			return ""
+		}
+		return value(s)
+	}}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatLocal(t time.Time, err error) string {
+	if err != nil {
+		return ""
+	}
+	return t.Format(localTimeLayout)
+}
+
+func formatDate(d whoop.Date, err error) string {
+	if err != nil {
+		return ""
+	}
+	return d.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatIntPtr(p *int) string {
+	if p == nil {
+		return ""
+	}
+	return strconv.Itoa(*p)
+}
@@ -0,0 +1,204 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// readCSV parses the output of a writer into a header and rows keyed by column.
+func readCSV(t *testing.T, data string) ([]string, []map[string]string) {
+	t.Helper()
+
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV output: %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatal("expected a header row")
+	}
+	var rows []map[string]string
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(rec))
+		for i, v := range rec {
+			row[records[0][i]] = v
+		}
+		rows = append(rows, row)
+	}
+	return records[0], rows
+}
+
+func TestCycleWriter(t *testing.T) {
+	start := time.Date(2026, 2, 24, 5, 0, 0, 0, time.UTC)
+	cycles := []whoop.Cycle{
+		{
+			ID:             123,
+			UserID:         999,
+			Start:          start,
+			TimezoneOffset: "-08:00",
+			ScoreState:     whoop.ScoreStateScored,
+			Score:          &whoop.Score{Strain: 12.4, Kilojoule: 4184, AverageHeartRate: 65, MaxHeartRate: 185},
+		},
+		{ID: 124, Start: start.Add(24 * time.Hour), TimezoneOffset: "bogus", ScoreState: whoop.ScoreStatePendingScore},
+	}
+
+	var buf bytes.Buffer
+	w := NewCycleWriter(&buf, CSVOptions{LocalTime: true, Energy: Kilocalories})
+	if err := w.WriteAll(cycles); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header, rows := readCSV(t, buf.String())
+	want := "id,user_id,created_at,updated_at,start,end,timezone_offset,start_local,end_local,local_date," +
+		"score_state,strain,energy_kcal,average_heart_rate,max_heart_rate"
+	if got := strings.Join(header, ","); got != want {
+		t.Errorf("unexpected header:\n got %s\nwant %s", got, want)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first["start"] != "2026-02-24T05:00:00Z" || first["end"] != "" || first["created_at"] != "" {
+		t.Errorf("unexpected timestamps: %+v", first)
+	}
+	if first["start_local"] != "2026-02-23 21:00:00" || first["local_date"] != "2026-02-23" {
+		t.Errorf("unexpected local columns: %+v", first)
+	}
+	if first["energy_kcal"] != "1000" || first["strain"] != "12.4" {
+		t.Errorf("unexpected score columns: %+v", first)
+	}
+
+	second := rows[1]
+	if second["start_local"] != "" || second["strain"] != "" || second["score_state"] != "PENDING_SCORE" {
+		t.Errorf("expected empty local and score columns, got %+v", second)
+	}
+}
+
+func TestWriter_EmptyFlushWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewRecoveryWriter(&buf, CSVOptions{Temperature: Fahrenheit})
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join(w.Columns(), ",") + "\n"
+	if buf.String() != want {
+		t.Errorf("expected only the header, got %q", buf.String())
+	}
+	if !strings.HasSuffix(want, ",skin_temp_f\n") {
+		t.Errorf("expected Fahrenheit column, got %q", want)
+	}
+
+	// Flushing again must not repeat the header.
+	if err := w.Flush(); err != nil || buf.String() != want {
+		t.Errorf("expected a single header after repeated flushes, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestSleepAndRecoveryUnits(t *testing.T) {
+	sleep := whoop.Sleep{
+		ID:             "slp",
+		Start:          time.Date(2026, 2, 24, 6, 0, 0, 0, time.UTC),
+		End:            time.Date(2026, 2, 24, 14, 0, 0, 0, time.UTC),
+		TimezoneOffset: "-08:00",
+		ScoreState:     whoop.ScoreStateScored,
+		Score: &whoop.SleepScore{
+			StageSummary: &whoop.StageSummary{
+				TotalLightSleepTimeMilli:    4 * 3600000,
+				TotalSlowWaveSleepTimeMilli: 90 * 60000,
+				TotalRemSleepTimeMilli:      90 * 60000,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := NewSleepWriter(&buf, CSVOptions{LocalTime: true, Duration: Hours})
+	if err := w.Write(&sleep); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, rows := readCSV(t, buf.String())
+	row := rows[0]
+	if row["total_sleep_time_h"] != "7" || row["total_light_sleep_time_h"] != "4" {
+		t.Errorf("unexpected duration columns: %+v", row)
+	}
+	if row["sleep_need_baseline_h"] != "" {
+		t.Errorf("expected empty sleep need without SleepNeeded, got %q", row["sleep_need_baseline_h"])
+	}
+	if row["local_date"] != "2026-02-24" || row["end_local"] != "2026-02-24 06:00:00" {
+		t.Errorf("expected local wake-up date, got %+v", row)
+	}
+
+	buf.Reset()
+	rw := NewRecoveryWriter(&buf, CSVOptions{Temperature: Fahrenheit})
+	err := rw.Write(&whoop.Recovery{CycleID: 1, ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{SkinTempCelsius: 35}})
+	if err != nil || rw.Flush() != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, rows := readCSV(t, buf.String()); rows[0]["skin_temp_f"] != "95" {
+		t.Errorf("expected 95F, got %+v", rows[0])
+	}
+}
+
+func TestWriteWorkoutPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/activity/workout", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("nextToken") == "" {
+			_, _ = w.Write([]byte(`{"records": [{
+				"id": "w1", "sport_id": 0, "start": "2026-02-24T14:00:00Z", "end": "2026-02-24T15:00:00Z",
+				"timezone_offset": "-08:00", "score_state": "SCORED",
+				"score": {"strain": 8.5, "kilojoule": 1000, "distance_meter": 10000,
+					"zone_durations": {"zone_two_milli": 1800000, "zone_three_milli": 1800000}}
+			}], "next_token": "page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records": [{
+			"id": "w2", "sport_id": 1, "start": "2026-02-25T14:00:00Z", "end": "2026-02-25T16:00:00Z",
+			"timezone_offset": "-08:00", "score_state": "PENDING_SCORE"
+		}], "next_token": ""}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := whoop.NewClient(whoop.WithBaseURL(ts.URL), whoop.WithRateLimiting(false))
+	ctx := context.Background()
+	page, err := client.Workout.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewWorkoutWriter(&buf, CSVOptions{Distance: Kilometers, Duration: Minutes})
+	if err := WriteWorkoutPages(ctx, w, page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, rows := readCSV(t, buf.String())
+	if len(rows) != 2 {
+		t.Fatalf("expected a row from each page, got %d", len(rows))
+	}
+	first := rows[0]
+	if first["sport_name"] != "Running" || first["distance_km"] != "10" || first["duration_min"] != "60" {
+		t.Errorf("unexpected workout row: %+v", first)
+	}
+	if first["zone_two_min"] != "30" || first["zone_five_min"] != "0" {
+		t.Errorf("unexpected zone columns: %+v", first)
+	}
+	if second := rows[1]; second["id"] != "w2" || second["distance_km"] != "" || second["duration_min"] != "120" {
+		t.Errorf("unexpected second row: %+v", second)
+	}
+}
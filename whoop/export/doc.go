@@ -0,0 +1,85 @@
+// Package export writes WHOOP records to file formats for use outside Go.
+//
+// Writers stream one record at a time and never buffer a full collection, so
+// they can be fed directly from the whoop client's paginated List methods.
+//
+// # CSV
+//
+// Each resource type has a CSV writer with stable snake_case columns. Nested
+// score fields are flattened into the same row and left empty for unscored
+// records. Column names carry their unit, so changing CSVOptions never
+// silently changes the meaning of an existing column:
+//
+//	w := export.NewSleepWriter(os.Stdout, export.CSVOptions{
+//	    LocalTime: true,
+//	    Duration:  export.Hours,
+//	})
+//	page, err := client.Sleep.List(ctx, &whoop.ListOptions{Limit: 25})
+//	if err != nil {
+//	    return err
+//	}
+//	if err := export.WriteSleepPages(ctx, w, page); err != nil {
+//	    return err
+//	}
+//
+// # NDJSON Archives
+//
+// ArchiveWriter writes a header line followed by one {"type", "data"} line per
+// record. ArchiveAll copies records from the API without decoding them, so
+// fields the model types do not yet define survive a round trip. ArchiveReader
+// reads them back, and each ArchiveRecord decodes into its model type:
+//
+//	r, err := export.NewArchiveReader(f)
+//	for {
+//	    rec, err := r.Next()
+//	    if errors.Is(err, io.EOF) {
+//	        break
+//	    }
+//	    if rec.Type == export.RecordCycle {
+//	        cycle, err := rec.Cycle()
+//	    }
+//	}
+//
+// # InfluxDB
+//
+// LineWriter writes one line protocol point per record to the whoop_cycle,
+// whoop_sleep, whoop_workout and whoop_recovery measurements, tagged with
+// user and score_state (plus nap for sleeps and sport for workouts). Its
+// output can go to a file, or to an InfluxWriter that posts it to an
+// InfluxDB v2 write endpoint:
+//
+//	iw := export.NewInfluxWriter(export.InfluxConfig{URL: url, Org: org, Bucket: bucket, Token: token})
+//	lw := export.NewLineWriter(iw, export.LineOptions{User: "alice"})
+//	if err := lw.WriteCycle(cycle); err != nil {
+//	    return err
+//	}
+//	if err := lw.Flush(); err != nil {
+//	    return err
+//	}
+//	err := iw.Send(ctx)
+//
+// # iCalendar
+//
+// Calendar turns sleeps and workouts into VEVENTs in each record's own local
+// time, with summaries such as "Running — strain 12.4" and the key metrics in
+// the description. UIDs derive from record IDs, so re-importing a calendar
+// updates events instead of duplicating them. NewCalendarFeed serves recent
+// records as a feed calendar applications can subscribe to:
+//
+//	http.Handle("/whoop.ics", export.NewCalendarFeed(client, 30*24*time.Hour, export.ICSOptions{Name: "WHOOP"}))
+//
+// # Apple Health
+//
+// HealthWriter writes the XML schema of an Apple Health export: recoveries as
+// HRV, resting heart rate and oxygen saturation records, sleeps as sleep
+// analysis categories, and workouts with their energy burned. WHOOP reports
+// sleep stage totals rather than a timeline, so stage records are an
+// approximation; see HealthWriter for details.
+//
+// # TCX and FIT
+//
+// WriteTCX and WriteFIT encode a single workout as a summary activity file for
+// training tools: one lap with the workout's sport, duration, energy,
+// distance and heart rate. The API has no per-second samples, so neither file
+// carries a detailed track.
+package export
@@ -0,0 +1,325 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// FIT protocol constants.
+const (
+	fitHeaderSize      = 14
+	fitProtocolVersion = 0x20 // 2.0
+	fitProfileVersion  = 2132 // 21.32
+
+	// fitManufacturerDevelopment identifies files not written by a device.
+	fitManufacturerDevelopment = 255
+)
+
+// fitEpoch is the zero of FIT date_time values.
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// FIT global message numbers.
+const (
+	fitMesgFileID   = 0
+	fitMesgSession  = 18
+	fitMesgLap      = 19
+	fitMesgEvent    = 21
+	fitMesgActivity = 34
+)
+
+// FIT base types.
+const (
+	fitEnum   = 0x00
+	fitUint8  = 0x02
+	fitUint16 = 0x84
+	fitUint32 = 0x86
+)
+
+// FIT enum values.
+const (
+	fitFileActivity          = 4
+	fitEventTimer            = 0
+	fitEventLap              = 9
+	fitEventSession          = 8
+	fitEventActivity         = 26
+	fitEventTypeStart        = 0
+	fitEventTypeStop         = 1
+	fitEventTypeStopAll      = 4
+	fitActivityManual        = 0
+	fitSubSportGeneric       = 0
+	fitSubSportMountain      = 8
+	fitSubSportIndoorCycling = 6
+)
+
+// FIT sport values.
+const (
+	FITSportGeneric          = 0
+	FITSportRunning          = 1
+	FITSportCycling          = 2
+	FITSportFitnessEquipment = 4
+	FITSportSwimming         = 5
+	FITSportBasketball       = 6
+	FITSportSoccer           = 7
+	FITSportTennis           = 8
+	FITSportAmericanFootball = 9
+	FITSportTraining         = 10
+	FITSportWalking          = 11
+	FITSportCrossCountrySki  = 12
+	FITSportAlpineSkiing     = 13
+	FITSportSnowboarding     = 14
+	FITSportRowing           = 15
+	FITSportHiking           = 17
+	FITSportMultisport       = 18
+	FITSportGolf             = 25
+	FITSportHorsebackRiding  = 27
+	FITSportInlineSkating    = 30
+	FITSportRockClimbing     = 31
+	FITSportSailing          = 32
+	FITSportIceSkating       = 33
+	FITSportStandUpPaddle    = 37
+	FITSportSurfing          = 38
+	FITSportWakeboarding     = 39
+	FITSportWaterSkiing      = 40
+	FITSportKayaking         = 41
+	FITSportKitesurfing      = 44
+	FITSportBoxing           = 47
+)
+
+// fitSports maps WHOOP sport IDs to FIT sports. Sports without a close match
+// are FITSportGeneric.
+var fitSports = map[int]uint8{
+	0:   FITSportRunning,
+	1:   FITSportCycling,
+	17:  FITSportBasketball,
+	18:  FITSportRowing,
+	21:  FITSportAmericanFootball,
+	22:  FITSportGolf,
+	28:  FITSportSailing,
+	29:  FITSportAlpineSkiing,
+	30:  FITSportSoccer,
+	33:  FITSportSwimming,
+	34:  FITSportTennis,
+	39:  FITSportBoxing,
+	45:  FITSportTraining,
+	47:  FITSportCrossCountrySki,
+	48:  FITSportTraining,
+	49:  FITSportMultisport,
+	52:  FITSportHiking,
+	53:  FITSportHorsebackRiding,
+	55:  FITSportKayaking,
+	57:  FITSportCycling,
+	59:  FITSportTraining,
+	60:  FITSportRockClimbing,
+	61:  FITSportStandUpPaddle,
+	62:  FITSportMultisport,
+	63:  FITSportWalking,
+	64:  FITSportSurfing,
+	65:  FITSportFitnessEquipment,
+	66:  FITSportFitnessEquipment,
+	91:  FITSportSnowboarding,
+	96:  FITSportTraining,
+	97:  FITSportCycling,
+	102: FITSportInlineSkating,
+	123: FITSportTraining,
+	239: FITSportIceSkating,
+	252: FITSportWalking,
+	253: FITSportRunning,
+	264: FITSportKitesurfing,
+	266: FITSportWalking,
+	267: FITSportWaterSkiing,
+	268: FITSportWakeboarding,
+}
+
+// FITSport returns the FIT sport for a WHOOP sport ID.
+func FITSport(sportID int) uint8 {
+	return fitSports[sportID]
+}
+
+// fitSubSport refines the FIT sport where WHOOP distinguishes variants.
+func fitSubSport(sportID int) uint8 {
+	switch sportID {
+	case 57: // Mountain Biking
+		return fitSubSportMountain
+	case 97: // Spin
+		return fitSubSportIndoorCycling
+	default:
+		return fitSubSportGeneric
+	}
+}
+
+// fitField is a field of a FIT message: its definition and its value, which
+// is written little-endian in size bytes.
+type fitField struct {
+	num      uint8
+	size     uint8
+	baseType uint8
+	value    uint64
+}
+
+func fitU8(num uint8, v uint8) fitField        { return fitField{num, 1, fitUint8, uint64(v)} }
+func fitEnumField(num uint8, v uint8) fitField { return fitField{num, 1, fitEnum, uint64(v)} }
+func fitU16(num uint8, v uint16) fitField      { return fitField{num, 2, fitUint16, uint64(v)} }
+func fitU32(num uint8, v uint32) fitField      { return fitField{num, 4, fitUint32, uint64(v)} }
+
+// fitEncoder writes FIT messages, each preceded by its definition. Every
+// message uses local message type 0, redefined before each use.
+type fitEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *fitEncoder) message(global uint16, fields ...fitField) {
+	// Definition message: header, reserved, architecture (little-endian),
+	// global message number and field definitions.
+	e.buf.WriteByte(0x40)
+	e.buf.WriteByte(0)
+	e.buf.WriteByte(0)
+	e.buf.Write(binary.LittleEndian.AppendUint16(nil, global))
+	e.buf.WriteByte(byte(len(fields)))
+	for _, f := range fields {
+		e.buf.Write([]byte{f.num, f.size, f.baseType})
+	}
+
+	// Data message.
+	e.buf.WriteByte(0x00)
+	for _, f := range fields {
+		for i := range f.size {
+			e.buf.WriteByte(byte(f.value >> (8 * i)))
+		}
+	}
+}
+
+// WriteFIT writes w as a FIT activity file with one session and one lap
+// summarizing the workout: sport, elapsed time, energy, distance, ascent and
+// average and maximum heart rate. There are no per-second records, since the
+// API does not provide them. Values missing from an unscored workout are
+// written as FIT invalid values.
+func WriteFIT(out io.Writer, w *whoop.Workout) error {
+	start, end := fitTime(w.Start), fitTime(w.End)
+	elapsed := uint32(max(w.Duration().Milliseconds(), 0))
+	sport := FITSport(w.SportID)
+
+	distance, ascent := uint32(math.MaxUint32), uint16(math.MaxUint16)
+	calories := uint16(math.MaxUint16)
+	avgHR, maxHR := uint8(math.MaxUint8), uint8(math.MaxUint8)
+	if w.IsScored() {
+		s := w.Score
+		calories = uint16(min(math.Round(s.Energy().Kilocalories()), math.MaxUint16-1))
+		avgHR, maxHR = fitHeartRate(s.AverageHeartRate), fitHeartRate(s.MaxHeartRate)
+		if d, ok := s.Distance(); ok {
+			distance = uint32(min(math.Round(d.Meters()*100), math.MaxUint32-1))
+		}
+		if d, ok := s.AltitudeGain(); ok {
+			ascent = uint16(min(math.Round(max(d.Meters(), 0)), math.MaxUint16-1))
+		}
+	}
+
+	var e fitEncoder
+	e.message(fitMesgFileID,
+		fitEnumField(0, fitFileActivity),
+		fitU16(1, fitManufacturerDevelopment),
+		fitU16(2, 0),
+		fitU32(4, fitTime(stampOf(w.CreatedAt, w.Start))),
+	)
+	e.message(fitMesgEvent, fitU32(253, start), fitEnumField(0, fitEventTimer), fitEnumField(1, fitEventTypeStart))
+	e.message(fitMesgEvent, fitU32(253, end), fitEnumField(0, fitEventTimer), fitEnumField(1, fitEventTypeStopAll))
+	e.message(fitMesgLap,
+		fitU16(254, 0),
+		fitU32(253, end),
+		fitEnumField(0, fitEventLap),
+		fitEnumField(1, fitEventTypeStop),
+		fitU32(2, start),
+		fitU32(7, elapsed),
+		fitU32(8, elapsed),
+		fitU32(9, distance),
+		fitU16(11, calories),
+		fitU8(15, avgHR),
+		fitU8(16, maxHR),
+		fitU16(21, ascent),
+		fitEnumField(25, sport),
+	)
+	e.message(fitMesgSession,
+		fitU16(254, 0),
+		fitU32(253, end),
+		fitEnumField(0, fitEventSession),
+		fitEnumField(1, fitEventTypeStop),
+		fitU32(2, start),
+		fitEnumField(5, sport),
+		fitEnumField(6, fitSubSport(w.SportID)),
+		fitU32(7, elapsed),
+		fitU32(8, elapsed),
+		fitU32(9, distance),
+		fitU16(11, calories),
+		fitU8(16, avgHR),
+		fitU8(17, maxHR),
+		fitU16(22, ascent),
+		fitU16(25, 0),
+		fitU16(26, 1),
+	)
+	local := end
+	if loc, err := whoop.ParseTimezoneOffset(w.TimezoneOffset); err == nil {
+		_, offset := w.End.In(loc).Zone()
+		local = uint32(int64(end) + int64(offset))
+	}
+	e.message(fitMesgActivity,
+		fitU32(253, end),
+		fitU32(0, elapsed),
+		fitU16(1, 1),
+		fitEnumField(2, fitActivityManual),
+		fitEnumField(3, fitEventActivity),
+		fitEnumField(4, fitEventTypeStop),
+		fitU32(5, local),
+	)
+
+	data := e.buf.Bytes()
+	header := make([]byte, fitHeaderSize, fitHeaderSize+len(data)+2)
+	header[0] = fitHeaderSize
+	header[1] = fitProtocolVersion
+	binary.LittleEndian.PutUint16(header[2:], fitProfileVersion)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(data)))
+	copy(header[8:], ".FIT")
+	binary.LittleEndian.PutUint16(header[12:], fitCRC(0, header[:12]))
+
+	file := append(header, data...)
+	file = binary.LittleEndian.AppendUint16(file, fitCRC(0, file))
+	_, err := out.Write(file)
+	return err
+}
+
+// fitTime converts t to a FIT date_time. Times before the FIT epoch are
+// clamped to it.
+func fitTime(t time.Time) uint32 {
+	return uint32(max(t.Unix()-fitEpoch.Unix(), 0))
+}
+
+// fitHeartRate converts a heart rate to a FIT uint8, with 0 as invalid.
+func fitHeartRate(bpm int) uint8 {
+	if bpm <= 0 {
+		return math.MaxUint8
+	}
+	return uint8(min(bpm, math.MaxUint8-1))
+}
+
+// fitCRCTable is the nibble table of the CRC-16 used by FIT files.
+var fitCRCTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// fitCRC updates crc with data using the FIT CRC-16 algorithm.
+func fitCRC(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		tmp := fitCRCTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ fitCRCTable[b&0xF]
+
+		tmp = fitCRCTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ fitCRCTable[(b>>4)&0xF]
+	}
+	return crc
+}
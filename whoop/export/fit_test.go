@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// fitMessage is a decoded FIT data message, with fields keyed by number.
+type fitMessage struct {
+	global uint16
+	fields map[uint8]uint64
+}
+
+// decodeFIT checks the framing of a FIT file and decodes its messages. It
+// supports only what WriteFIT produces: normal headers and little-endian
+// definitions.
+func decodeFIT(t *testing.T, data []byte) []fitMessage {
+	t.Helper()
+	if len(data) < fitHeaderSize+2 || data[0] != fitHeaderSize || string(data[8:12]) != ".FIT" {
+		t.Fatalf("invalid FIT header % x", data[:min(len(data), fitHeaderSize)])
+	}
+	if got := binary.LittleEndian.Uint16(data[12:]); got != fitCRC(0, data[:12]) {
+		t.Errorf("header CRC %#04x does not match", got)
+	}
+	size := int(binary.LittleEndian.Uint32(data[4:]))
+	if fitHeaderSize+size+2 != len(data) {
+		t.Fatalf("data size %d does not match file length %d", size, len(data))
+	}
+	if fitCRC(0, data) != 0 {
+		t.Error("file CRC does not match")
+	}
+
+	type definition struct {
+		global uint16
+		fields [][2]uint8 // number, size
+	}
+	defs := map[uint8]definition{}
+	var msgs []fitMessage
+	r := bytes.NewReader(data[fitHeaderSize : fitHeaderSize+size])
+	for r.Len() > 0 {
+		header, _ := r.ReadByte()
+		local := header & 0x0F
+		if header&0x40 != 0 {
+			var fixed [5]byte
+			_, _ = r.Read(fixed[:])
+			if fixed[1] != 0 {
+				t.Fatal("expected little-endian definitions")
+			}
+			def := definition{global: binary.LittleEndian.Uint16(fixed[2:])}
+			for range fixed[4] {
+				var f [3]byte
+				_, _ = r.Read(f[:])
+				def.fields = append(def.fields, [2]uint8{f[0], f[1]})
+			}
+			defs[local] = def
+			continue
+		}
+		def, ok := defs[local]
+		if !ok {
+			t.Fatalf("data message for undefined local type %d", local)
+		}
+		msg := fitMessage{global: def.global, fields: map[uint8]uint64{}}
+		for _, f := range def.fields {
+			var v uint64
+			for i := range f[1] {
+				b, _ := r.ReadByte()
+				v |= uint64(b) << (8 * i)
+			}
+			msg.fields[f[0]] = v
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func TestFITCRC(t *testing.T) {
+	// The FIT CRC is CRC-16/ARC, whose check value is 0xBB3D.
+	if got := fitCRC(0, []byte("123456789")); got != 0xBB3D {
+		t.Errorf("got %#04x, want 0xbb3d", got)
+	}
+}
+
+func TestWriteFIT_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteFIT(&buf, goldenWorkout()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkGolden(t, "workout.fit", buf.Bytes())
+}
+
+func TestWriteFIT(t *testing.T) {
+	w := goldenWorkout()
+	var buf bytes.Buffer
+	if err := WriteFIT(&buf, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msgs := decodeFIT(t, buf.Bytes())
+
+	var globals []uint16
+	for _, m := range msgs {
+		globals = append(globals, m.global)
+	}
+	want := []uint16{fitMesgFileID, fitMesgEvent, fitMesgEvent, fitMesgLap, fitMesgSession, fitMesgActivity}
+	if len(globals) != len(want) {
+		t.Fatalf("unexpected messages %v", globals)
+	}
+	for i := range want {
+		if globals[i] != want[i] {
+			t.Fatalf("unexpected messages %v", globals)
+		}
+	}
+
+	start := uint64(w.Start.Unix() - fitEpoch.Unix())
+	session := msgs[4].fields
+	for num, want := range map[uint8]uint64{
+		2:  start,
+		5:  FITSportRunning,
+		7:  2910000, // 48m30s in ms
+		9:  1001250, // 10012.5 m in cm
+		11: 693,     // 2900 kJ in kcal
+		16: 156,
+		17: 183,
+		22: 86,
+		26: 1,
+	} {
+		if session[num] != want {
+			t.Errorf("session field %d = %d, want %d", num, session[num], want)
+		}
+	}
+	if local := msgs[5].fields[5]; local != start+2910-5*3600 {
+		t.Errorf("unexpected local timestamp %d", local)
+	}
+}
+
+func TestWriteFIT_Unscored(t *testing.T) {
+	start := time.Date(2026, 2, 24, 14, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := WriteFIT(&buf, &whoop.Workout{SportID: 9999, Start: start, End: start.Add(time.Hour), ScoreState: whoop.ScoreStatePendingScore})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lap := decodeFIT(t, buf.Bytes())[3]
+	if lap.global != fitMesgLap {
+		t.Fatalf("expected a lap, got message %d", lap.global)
+	}
+	for num, want := range map[uint8]uint64{9: 0xFFFFFFFF, 11: 0xFFFF, 15: 0xFF, 16: 0xFF, 21: 0xFFFF, 25: FITSportGeneric, 7: 3600000} {
+		if lap.fields[num] != want {
+			t.Errorf("lap field %d = %#x, want %#x", num, lap.fields[num], want)
+		}
+	}
+}
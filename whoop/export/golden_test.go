@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// checkGolden compares got with testdata/name, or rewrites the file when the
+// tests run with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update if the change is intended):\n got %q\nwant %q", path, got, want)
+	}
+}
+
+// goldenWorkout is the scored run encoded by the golden file tests.
+func goldenWorkout() *whoop.Workout {
+	start := time.Date(2026, 2, 24, 14, 0, 0, 0, time.UTC)
+	distance, gain, change := 10012.5, 86.4, -3.2
+	return &whoop.Workout{
+		ID:             "ecfc6a15-4661-442f-a9a4-f160dd7afae8",
+		CreatedAt:      start.Add(50 * time.Minute),
+		Start:          start,
+		End:            start.Add(48*time.Minute + 30*time.Second),
+		TimezoneOffset: "-05:00",
+		SportID:        0,
+		ScoreState:     whoop.ScoreStateScored,
+		Score: &whoop.WorkoutScore{
+			Strain:              13.7,
+			AverageHeartRate:    156,
+			MaxHeartRate:        183,
+			Kilojoule:           2900,
+			DistanceMeter:       &distance,
+			AltitudeGainMeter:   &gain,
+			AltitudeChangeMeter: &change,
+		},
+	}
+}
@@ -0,0 +1,426 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// healthDateLayout is the timestamp format of Apple Health export files.
+const healthDateLayout = "2006-01-02 15:04:05 -0700"
+
+// HealthKit type identifiers written by HealthWriter.
+const (
+	HKHeartRateVariabilitySDNN = "HKQuantityTypeIdentifierHeartRateVariabilitySDNN"
+	HKRestingHeartRate         = "HKQuantityTypeIdentifierRestingHeartRate"
+	HKOxygenSaturation         = "HKQuantityTypeIdentifierOxygenSaturation"
+	HKRespiratoryRate          = "HKQuantityTypeIdentifierRespiratoryRate"
+	HKSleepAnalysis            = "HKCategoryTypeIdentifierSleepAnalysis"
+	HKHeartRate                = "HKQuantityTypeIdentifierHeartRate"
+	HKActiveEnergyBurned       = "HKQuantityTypeIdentifierActiveEnergyBurned"
+)
+
+// Sleep analysis category values written by HealthWriter.
+const (
+	HKSleepInBed      = "HKCategoryValueSleepAnalysisInBed"
+	HKSleepAsleepCore = "HKCategoryValueSleepAnalysisAsleepCore"
+	HKSleepAsleepDeep = "HKCategoryValueSleepAnalysisAsleepDeep"
+	HKSleepAsleepREM  = "HKCategoryValueSleepAnalysisAsleepREM"
+	HKSleepAwake      = "HKCategoryValueSleepAnalysisAwake"
+)
+
+// hkWorkoutTypeOther is the activity type of sports without a close match.
+const hkWorkoutTypeOther = "HKWorkoutActivityTypeOther"
+
+// HealthOptions configures a HealthWriter.
+type HealthOptions struct {
+	// SourceName is the sourceName attribute of every record. Empty uses
+	// "WHOOP".
+	SourceName string
+
+	// Locale is the locale attribute of the HealthData element. Empty uses
+	// "en_US".
+	Locale string
+
+	// ExportDate is written as the file's ExportDate. Zero uses the time the
+	// header is written.
+	ExportDate time.Time
+}
+
+// healthRecord is a Record element of an Apple Health export.
+type healthRecord struct {
+	XMLName      xml.Name        `xml:"Record"`
+	Type         string          `xml:"type,attr"`
+	SourceName   string          `xml:"sourceName,attr"`
+	Unit         string          `xml:"unit,attr,omitempty"`
+	CreationDate string          `xml:"creationDate,attr,omitempty"`
+	StartDate    string          `xml:"startDate,attr"`
+	EndDate      string          `xml:"endDate,attr"`
+	Value        string          `xml:"value,attr"`
+	Metadata     []healthMetaKey `xml:"MetadataEntry"`
+}
+
+// healthWorkout is a Workout element of an Apple Health export.
+type healthWorkout struct {
+	XMLName               xml.Name            `xml:"Workout"`
+	ActivityType          string              `xml:"workoutActivityType,attr"`
+	Duration              string              `xml:"duration,attr"`
+	DurationUnit          string              `xml:"durationUnit,attr"`
+	TotalDistance         string              `xml:"totalDistance,attr,omitempty"`
+	TotalDistanceUnit     string              `xml:"totalDistanceUnit,attr,omitempty"`
+	TotalEnergyBurned     string              `xml:"totalEnergyBurned,attr,omitempty"`
+	TotalEnergyBurnedUnit string              `xml:"totalEnergyBurnedUnit,attr,omitempty"`
+	SourceName            string              `xml:"sourceName,attr"`
+	CreationDate          string              `xml:"creationDate,attr,omitempty"`
+	StartDate             string              `xml:"startDate,attr"`
+	EndDate               string              `xml:"endDate,attr"`
+	Metadata              []healthMetaKey     `xml:"MetadataEntry"`
+	Statistics            []healthWorkoutStat `xml:"WorkoutStatistics"`
+}
+
+type healthWorkoutStat struct {
+	Type      string `xml:"type,attr"`
+	StartDate string `xml:"startDate,attr"`
+	EndDate   string `xml:"endDate,attr"`
+	Average   string `xml:"average,attr,omitempty"`
+	Maximum   string `xml:"maximum,attr,omitempty"`
+	Sum       string `xml:"sum,attr,omitempty"`
+	Unit      string `xml:"unit,attr"`
+}
+
+type healthMetaKey struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// sleepWindow is where and when a written sleep took place, used to date
+// the recovery that follows it.
+type sleepWindow struct {
+	start, end time.Time
+	loc        *time.Location
+}
+
+// HealthWriter writes records in the XML schema of an Apple Health export
+// (export.xml), for tools that import or merge that format.
+//
+// Recoveries become heart rate variability, resting heart rate and oxygen
+// saturation records. WHOOP measures HRV as RMSSD while HealthKit's type is
+// SDNN; the values are written as is, so they are comparable with other WHOOP
+// data but not with SDNN from other sources. A recovery is dated over its
+// sleep when that sleep was written before it, and at its CreatedAt
+// otherwise.
+//
+// Sleeps become an InBed record spanning the sleep and one record per stage.
+// WHOOP reports only stage totals, not a timeline, so the stages are laid out
+// back to back from the start of the sleep (light, deep, REM, then awake):
+// their durations are exact but their times are an approximation.
+//
+// Workouts carry their activity type, duration, energy burned, distance and
+// heart rate statistics. Timestamps are in each record's own timezone.
+//
+// The header is written before the first record, or by Close if no records
+// were written. A HealthWriter is not safe for concurrent use.
+type HealthWriter struct {
+	w           io.Writer
+	enc         *xml.Encoder
+	opts        HealthOptions
+	wroteHeader bool
+	sleeps      map[string]sleepWindow
+}
+
+// NewHealthWriter returns a HealthWriter that writes to w.
+func NewHealthWriter(w io.Writer, opts HealthOptions) *HealthWriter {
+	if opts.SourceName == "" {
+		opts.SourceName = "WHOOP"
+	}
+	if opts.Locale == "" {
+		opts.Locale = "en_US"
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	return &HealthWriter{w: w, enc: enc, opts: opts, sleeps: make(map[string]sleepWindow)}
+}
+
+// WriteRecovery writes the records of a scored recovery. Unscored recoveries
+// are skipped.
+func (h *HealthWriter) WriteRecovery(r *whoop.Recovery) error {
+	if !r.IsScored() {
+		return nil
+	}
+	start, end, loc := r.CreatedAt, r.CreatedAt, time.UTC
+	if s, ok := h.sleeps[r.SleepID]; ok {
+		start, end, loc = s.start, s.end, s.loc
+	}
+	created := healthDate(r.CreatedAt, loc)
+	meta := []healthMetaKey{{"HKExternalUUID", "recovery-" + strconv.Itoa(r.CycleID)}}
+
+	records := []healthRecord{
+		{Type: HKHeartRateVariabilitySDNN, Unit: "ms", Value: formatFloat(r.Score.HrvRmssdMilli)},
+		{Type: HKRestingHeartRate, Unit: "count/min", Value: formatFloat(r.Score.RestingHeartRate)},
+	}
+	if r.Score.Spo2Percentage > 0 {
+		records = append(records, healthRecord{Type: HKOxygenSaturation, Unit: "%", Value: formatFloat(r.Score.Spo2Percentage / 100)})
+	}
+	for _, rec := range records {
+		rec.SourceName = h.opts.SourceName
+		rec.CreationDate = created
+		rec.StartDate = healthDate(start, loc)
+		rec.EndDate = healthDate(end, loc)
+		rec.Metadata = meta
+		if err := h.encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSleep writes the sleep analysis records of s and, when scored, its
+// respiratory rate.
+func (h *HealthWriter) WriteSleep(s *whoop.Sleep) error {
+	loc := recordLocation(s.TimezoneOffset)
+	h.sleeps[s.ID] = sleepWindow{start: s.Start, end: s.End, loc: loc}
+
+	created := healthDate(s.CreatedAt, loc)
+	meta := []healthMetaKey{{"HKExternalUUID", "sleep-" + s.ID}}
+	category := func(value string, start, end time.Time) healthRecord {
+		return healthRecord{
+			Type: HKSleepAnalysis, SourceName: h.opts.SourceName, CreationDate: created,
+			StartDate: healthDate(start, loc), EndDate: healthDate(end, loc),
+			Value: value, Metadata: meta,
+		}
+	}
+
+	if err := h.encode(category(HKSleepInBed, s.Start, s.End)); err != nil {
+		return err
+	}
+	if !s.IsScored() {
+		return nil
+	}
+
+	if st := s.Score.StageSummary; st != nil {
+		at := s.Start
+		for _, stage := range []struct {
+			value string
+			d     time.Duration
+		}{
+			{HKSleepAsleepCore, st.TotalLightSleepTime()},
+			{HKSleepAsleepDeep, st.TotalSlowWaveSleepTime()},
+			{HKSleepAsleepREM, st.TotalRemSleepTime()},
+			{HKSleepAwake, st.TotalAwakeTime()},
+		} {
+			end := at.Add(stage.d)
+			if end.After(s.End) {
+				end = s.End
+			}
+			if !end.After(at) {
+				continue
+			}
+			if err := h.encode(category(stage.value, at, end)); err != nil {
+				return err
+			}
+			at = end
+		}
+	}
+
+	if s.Score.RespiratoryRate > 0 {
+		return h.encode(healthRecord{
+			Type: HKRespiratoryRate, SourceName: h.opts.SourceName, Unit: "count/min", CreationDate: created,
+			StartDate: healthDate(s.Start, loc), EndDate: healthDate(s.End, loc),
+			Value: formatFloat(s.Score.RespiratoryRate), Metadata: meta,
+		})
+	}
+	return nil
+}
+
+// WriteWorkout writes a Workout element for w.
+func (h *HealthWriter) WriteWorkout(w *whoop.Workout) error {
+	loc := recordLocation(w.TimezoneOffset)
+	start, end := healthDate(w.Start, loc), healthDate(w.End, loc)
+
+	hw := healthWorkout{
+		ActivityType: HealthKitActivityType(w.SportID),
+		Duration:     strconv.FormatFloat(w.Duration().Minutes(), 'f', 2, 64),
+		DurationUnit: "min",
+		SourceName:   h.opts.SourceName,
+		CreationDate: healthDate(w.CreatedAt, loc),
+		StartDate:    start,
+		EndDate:      end,
+		Metadata:     []healthMetaKey{{"HKExternalUUID", "workout-" + w.ID}},
+	}
+	if w.IsScored() {
+		s := w.Score
+		kcal := strconv.FormatFloat(s.Energy().Kilocalories(), 'f', 1, 64)
+		hw.TotalEnergyBurned, hw.TotalEnergyBurnedUnit = kcal, "kcal"
+		if d, ok := s.Distance(); ok {
+			hw.TotalDistance, hw.TotalDistanceUnit = strconv.FormatFloat(d.Kilometers(), 'f', 3, 64), "km"
+		}
+		hw.Metadata = append(hw.Metadata, healthMetaKey{"WHOOPStrain", formatFloat(s.Strain)})
+		hw.Statistics = []healthWorkoutStat{
+			{Type: HKActiveEnergyBurned, StartDate: start, EndDate: end, Sum: kcal, Unit: "kcal"},
+			{Type: HKHeartRate, StartDate: start, EndDate: end, Average: strconv.Itoa(s.AverageHeartRate), Maximum: strconv.Itoa(s.MaxHeartRate), Unit: "count/min"},
+		}
+	}
+	return h.encode(hw)
+}
+
+// Close writes the header if no records were written, then the closing
+// HealthData tag, and flushes the output. It does not close the underlying
+// writer.
+func (h *HealthWriter) Close() error {
+	if err := h.writeHeader(); err != nil {
+		return err
+	}
+	if err := h.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "HealthData"}}); err != nil {
+		return err
+	}
+	if err := h.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(h.w, "\n")
+	return err
+}
+
+func (h *HealthWriter) writeHeader() error {
+	if h.wroteHeader {
+		return nil
+	}
+	h.wroteHeader = true
+
+	if _, err := io.WriteString(h.w, xml.Header); err != nil {
+		return err
+	}
+	root := xml.StartElement{
+		Name: xml.Name{Local: "HealthData"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "locale"}, Value: h.opts.Locale}},
+	}
+	if err := h.enc.EncodeToken(root); err != nil {
+		return err
+	}
+	exportDate := h.opts.ExportDate
+	if exportDate.IsZero() {
+		exportDate = time.Now()
+	}
+	return h.enc.Encode(struct {
+		XMLName xml.Name `xml:"ExportDate"`
+		Value   string   `xml:"value,attr"`
+	}{Value: exportDate.Format(healthDateLayout)})
+}
+
+func (h *HealthWriter) encode(v any) error {
+	if err := h.writeHeader(); err != nil {
+		return err
+	}
+	return h.enc.Encode(v)
+}
+
+// recordLocation returns the location of a record's TimezoneOffset, or UTC
+// when the offset is invalid.
+func recordLocation(offset string) *time.Location {
+	loc, err := whoop.ParseTimezoneOffset(offset)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func healthDate(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(healthDateLayout)
+}
+
+// healthKitActivityTypes maps WHOOP sport IDs to HKWorkoutActivityType names.
+var healthKitActivityTypes = map[int]string{
+	0:   "HKWorkoutActivityTypeRunning",
+	1:   "HKWorkoutActivityTypeCycling",
+	16:  "HKWorkoutActivityTypeBaseball",
+	17:  "HKWorkoutActivityTypeBasketball",
+	18:  "HKWorkoutActivityTypeRowing",
+	19:  "HKWorkoutActivityTypeFencing",
+	20:  "HKWorkoutActivityTypeHockey",
+	21:  "HKWorkoutActivityTypeAmericanFootball",
+	22:  "HKWorkoutActivityTypeGolf",
+	24:  "HKWorkoutActivityTypeHockey",
+	25:  "HKWorkoutActivityTypeLacrosse",
+	27:  "HKWorkoutActivityTypeRugby",
+	28:  "HKWorkoutActivityTypeSailing",
+	29:  "HKWorkoutActivityTypeDownhillSkiing",
+	30:  "HKWorkoutActivityTypeSoccer",
+	31:  "HKWorkoutActivityTypeSoftball",
+	32:  "HKWorkoutActivityTypeSquash",
+	33:  "HKWorkoutActivityTypeSwimming",
+	34:  "HKWorkoutActivityTypeTennis",
+	35:  "HKWorkoutActivityTypeTrackAndField",
+	36:  "HKWorkoutActivityTypeVolleyball",
+	37:  "HKWorkoutActivityTypeWaterPolo",
+	38:  "HKWorkoutActivityTypeWrestling",
+	39:  "HKWorkoutActivityTypeBoxing",
+	42:  "HKWorkoutActivityTypeCardioDance",
+	43:  "HKWorkoutActivityTypePilates",
+	44:  "HKWorkoutActivityTypeYoga",
+	45:  "HKWorkoutActivityTypeTraditionalStrengthTraining",
+	47:  "HKWorkoutActivityTypeCrossCountrySkiing",
+	48:  "HKWorkoutActivityTypeFunctionalStrengthTraining",
+	49:  "HKWorkoutActivityTypeMixedCardio",
+	51:  "HKWorkoutActivityTypeGymnastics",
+	52:  "HKWorkoutActivityTypeHiking",
+	53:  "HKWorkoutActivityTypeEquestrianSports",
+	55:  "HKWorkoutActivityTypePaddleSports",
+	56:  "HKWorkoutActivityTypeMartialArts",
+	57:  "HKWorkoutActivityTypeCycling",
+	59:  "HKWorkoutActivityTypeTraditionalStrengthTraining",
+	60:  "HKWorkoutActivityTypeClimbing",
+	61:  "HKWorkoutActivityTypePaddleSports",
+	62:  "HKWorkoutActivityTypeSwimBikeRun",
+	63:  "HKWorkoutActivityTypeWalking",
+	64:  "HKWorkoutActivityTypeSurfingSports",
+	65:  "HKWorkoutActivityTypeElliptical",
+	66:  "HKWorkoutActivityTypeStairClimbing",
+	70:  "HKWorkoutActivityTypeMindAndBody",
+	84:  "HKWorkoutActivityTypeJumpRope",
+	85:  "HKWorkoutActivityTypeAustralianFootball",
+	86:  "HKWorkoutActivityTypeSkatingSports",
+	91:  "HKWorkoutActivityTypeSnowboarding",
+	94:  "HKWorkoutActivityTypeMixedCardio",
+	96:  "HKWorkoutActivityTypeHighIntensityIntervalTraining",
+	97:  "HKWorkoutActivityTypeCycling",
+	98:  "HKWorkoutActivityTypeMartialArts",
+	100: "HKWorkoutActivityTypeCricket",
+	101: "HKWorkoutActivityTypePickleball",
+	102: "HKWorkoutActivityTypeSkatingSports",
+	103: "HKWorkoutActivityTypeCrossTraining",
+	107: "HKWorkoutActivityTypeBarre",
+	123: "HKWorkoutActivityTypeTraditionalStrengthTraining",
+	126: "HKWorkoutActivityTypeMixedCardio",
+	127: "HKWorkoutActivityTypeKickboxing",
+	128: "HKWorkoutActivityTypeFlexibility",
+	230: "HKWorkoutActivityTypeTableTennis",
+	231: "HKWorkoutActivityTypeBadminton",
+	234: "HKWorkoutActivityTypeDiscSports",
+	239: "HKWorkoutActivityTypeSkatingSports",
+	240: "HKWorkoutActivityTypeHandball",
+	248: "HKWorkoutActivityTypeHighIntensityIntervalTraining",
+	250: "HKWorkoutActivityTypeHighIntensityIntervalTraining",
+	252: "HKWorkoutActivityTypeWalking",
+	253: "HKWorkoutActivityTypeRunning",
+	258: "HKWorkoutActivityTypeBarre",
+	259: "HKWorkoutActivityTypeYoga",
+	261: "HKWorkoutActivityTypeStairs",
+	264: "HKWorkoutActivityTypeWaterSports",
+	266: "HKWorkoutActivityTypeWalking",
+	267: "HKWorkoutActivityTypeWaterSports",
+	268: "HKWorkoutActivityTypeWaterSports",
+}
+
+// HealthKitActivityType returns the HKWorkoutActivityType name for a WHOOP
+// sport ID, or "HKWorkoutActivityTypeOther" for sports without a close match.
+func HealthKitActivityType(sportID int) string {
+	if t, ok := healthKitActivityTypes[sportID]; ok {
+		return t
+	}
+	return hkWorkoutTypeOther
+}
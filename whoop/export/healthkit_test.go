@@ -0,0 +1,163 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// parsedHealth is the subset of an Apple Health export read back in tests.
+type parsedHealth struct {
+	Locale     string `xml:"locale,attr"`
+	ExportDate struct {
+		Value string `xml:"value,attr"`
+	} `xml:"ExportDate"`
+	Records  []healthRecord  `xml:"Record"`
+	Workouts []healthWorkout `xml:"Workout"`
+}
+
+func parseHealth(t *testing.T, data []byte) parsedHealth {
+	t.Helper()
+	var out parsedHealth
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, data)
+	}
+	return out
+}
+
+func TestHealthWriter(t *testing.T) {
+	start := time.Date(2026, 2, 24, 6, 0, 0, 0, time.UTC) // 22:00 at -08:00
+	distance := 5000.0
+
+	var buf bytes.Buffer
+	h := NewHealthWriter(&buf, HealthOptions{ExportDate: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)})
+	if err := h.WriteSleep(&whoop.Sleep{
+		ID: "s1", Start: start, End: start.Add(8 * time.Hour), TimezoneOffset: "-08:00",
+		CreatedAt: start.Add(8 * time.Hour), ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.SleepScore{
+			RespiratoryRate: 15.5,
+			StageSummary: &whoop.StageSummary{
+				TotalLightSleepTimeMilli: 4 * 3600000, TotalSlowWaveSleepTimeMilli: 1.5 * 3600000,
+				TotalRemSleepTimeMilli: 2 * 3600000, TotalAwakeTimeMilli: 3600000, // overruns the sleep by 30m
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.WriteRecovery(&whoop.Recovery{
+		CycleID: 7, SleepID: "s1", CreatedAt: start.Add(8*time.Hour + time.Minute), ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.RecoveryScore{HrvRmssdMilli: 48.5, RestingHeartRate: 52, Spo2Percentage: 96.5},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.WriteRecovery(&whoop.Recovery{CycleID: 8, ScoreState: whoop.ScoreStatePendingScore}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.WriteWorkout(&whoop.Workout{
+		ID: "w1", Start: start.Add(20 * time.Hour), End: start.Add(20*time.Hour + 45*time.Minute), TimezoneOffset: "-08:00",
+		SportID: 0, ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.WorkoutScore{Strain: 11.2, AverageHeartRate: 152, MaxHeartRate: 178, Kilojoule: 2092, DistanceMeter: &distance},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header+`<HealthData locale="en_US">`+"\n"+` <ExportDate value="2026-03-01 12:00:00 +0000"></ExportDate>`) {
+		t.Errorf("unexpected header:\n%s", buf.String())
+	}
+
+	out := parseHealth(t, buf.Bytes())
+	type row struct{ typ, value, start, end string }
+	var got []row
+	for _, r := range out.Records {
+		if r.SourceName != "WHOOP" {
+			t.Errorf("unexpected source %q", r.SourceName)
+		}
+		got = append(got, row{r.Type, r.Value, r.StartDate, r.EndDate})
+	}
+	want := []row{
+		{HKSleepAnalysis, HKSleepInBed, "2026-02-23 22:00:00 -0800", "2026-02-24 06:00:00 -0800"},
+		{HKSleepAnalysis, HKSleepAsleepCore, "2026-02-23 22:00:00 -0800", "2026-02-24 02:00:00 -0800"},
+		{HKSleepAnalysis, HKSleepAsleepDeep, "2026-02-24 02:00:00 -0800", "2026-02-24 03:30:00 -0800"},
+		{HKSleepAnalysis, HKSleepAsleepREM, "2026-02-24 03:30:00 -0800", "2026-02-24 05:30:00 -0800"},
+		{HKSleepAnalysis, HKSleepAwake, "2026-02-24 05:30:00 -0800", "2026-02-24 06:00:00 -0800"},
+		{HKRespiratoryRate, "15.5", "2026-02-23 22:00:00 -0800", "2026-02-24 06:00:00 -0800"},
+		{HKHeartRateVariabilitySDNN, "48.5", "2026-02-23 22:00:00 -0800", "2026-02-24 06:00:00 -0800"},
+		{HKRestingHeartRate, "52", "2026-02-23 22:00:00 -0800", "2026-02-24 06:00:00 -0800"},
+		{HKOxygenSaturation, "0.965", "2026-02-23 22:00:00 -0800", "2026-02-24 06:00:00 -0800"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d records, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("record %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+	if hrv := out.Records[6]; hrv.Unit != "ms" || hrv.CreationDate != "2026-02-24 06:01:00 -0800" ||
+		len(hrv.Metadata) != 1 || hrv.Metadata[0].Value != "recovery-7" {
+		t.Errorf("unexpected HRV record %+v", hrv)
+	}
+
+	if len(out.Workouts) != 1 {
+		t.Fatalf("expected 1 workout, got %d", len(out.Workouts))
+	}
+	w := out.Workouts[0]
+	if w.ActivityType != "HKWorkoutActivityTypeRunning" || w.Duration != "45.00" || w.DurationUnit != "min" ||
+		w.TotalEnergyBurned != "500.0" || w.TotalEnergyBurnedUnit != "kcal" ||
+		w.TotalDistance != "5.000" || w.TotalDistanceUnit != "km" ||
+		w.StartDate != "2026-02-24 18:00:00 -0800" || w.EndDate != "2026-02-24 18:45:00 -0800" {
+		t.Errorf("unexpected workout %+v", w)
+	}
+	if len(w.Statistics) != 2 || w.Statistics[1].Average != "152" || w.Statistics[1].Maximum != "178" {
+		t.Errorf("unexpected workout statistics %+v", w.Statistics)
+	}
+}
+
+func TestHealthWriter_UnscoredAndEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHealthWriter(&buf, HealthOptions{SourceName: "Alice's WHOOP", Locale: "en_GB"})
+	if err := h.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := parseHealth(t, buf.Bytes())
+	if out.Locale != "en_GB" || out.ExportDate.Value == "" || len(out.Records) != 0 {
+		t.Errorf("unexpected empty export %+v", out)
+	}
+
+	buf.Reset()
+	h = NewHealthWriter(&buf, HealthOptions{SourceName: "Alice's WHOOP"})
+	created := time.Date(2026, 2, 24, 15, 0, 0, 0, time.UTC)
+	if err := h.WriteSleep(&whoop.Sleep{ID: "s1", Start: created.Add(-time.Hour), End: created, TimezoneOffset: "bogus", ScoreState: whoop.ScoreStatePendingScore}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.WriteRecovery(&whoop.Recovery{CycleID: 1, SleepID: "elsewhere", CreatedAt: created, ScoreState: whoop.ScoreStateScored, Score: &whoop.RecoveryScore{HrvRmssdMilli: 40}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.WriteWorkout(&whoop.Workout{ID: "w1", SportID: 9999, ScoreState: whoop.ScoreStateUnscorable}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out = parseHealth(t, buf.Bytes())
+	if len(out.Records) != 3 || out.Records[0].Value != HKSleepInBed || out.Records[0].StartDate != "2026-02-24 14:00:00 +0000" {
+		t.Fatalf("expected only an InBed record for an unscored sleep, then HRV and RHR, got %+v", out.Records)
+	}
+	if out.Records[0].SourceName != "Alice's WHOOP" {
+		t.Errorf("unexpected source %q", out.Records[0].SourceName)
+	}
+	if r := out.Records[1]; r.StartDate != "2026-02-24 15:00:00 +0000" || r.EndDate != r.StartDate {
+		t.Errorf("expected a recovery without its sleep to be dated at CreatedAt, got %+v", r)
+	}
+	if w := out.Workouts[0]; w.ActivityType != "HKWorkoutActivityTypeOther" || w.TotalEnergyBurned != "" || len(w.Statistics) != 0 {
+		t.Errorf("unexpected unscored workout %+v", w)
+	}
+}
@@ -0,0 +1,349 @@
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// DefaultFeedLookback is how far back a calendar feed reaches when
+// NewCalendarFeed is given a non-positive lookback.
+const DefaultFeedLookback = 30 * 24 * time.Hour
+
+const (
+	icsProdID        = "-//arvarik//whoop-go//EN"
+	icsUIDDomain     = "whoop-go"
+	icsLocalLayout   = "20060102T150405"
+	icsUTCLayout     = "20060102T150405Z"
+	icsMaxLineOctets = 75
+)
+
+// ICSOptions configures a Calendar.
+type ICSOptions struct {
+	// Name is the calendar's display name (X-WR-CALNAME). Empty omits it.
+	Name string
+
+	// Energy and Distance select the units used in event descriptions.
+	Energy   EnergyUnit
+	Distance DistanceUnit
+}
+
+// icsEvent is a VEVENT, with text values not yet escaped.
+type icsEvent struct {
+	uid         string
+	stamp       time.Time
+	start, end  time.Time
+	tzid        string // empty writes UTC times
+	summary     string
+	description []string // one line per metric
+	category    string
+}
+
+// Calendar collects sleeps and workouts as iCalendar (RFC 5545) events.
+// Each event is in the local time of its record's TimezoneOffset, declared by
+// a VTIMEZONE per distinct offset; records with an invalid offset are written
+// in UTC. UIDs derive from record IDs, so importing an updated calendar
+// replaces events instead of duplicating them. A Calendar is not safe for
+// concurrent use.
+type Calendar struct {
+	opts   ICSOptions
+	events []icsEvent
+	zones  map[string]int // TZID -> offset in seconds
+}
+
+// NewCalendar returns an empty Calendar.
+func NewCalendar(opts ICSOptions) *Calendar {
+	return &Calendar{opts: opts, zones: make(map[string]int)}
+}
+
+// Len returns the number of events in the calendar.
+func (c *Calendar) Len() int {
+	return len(c.events)
+}
+
+// AddSleep adds an event for s, such as "Sleep — 7h 42m, 91%" or
+// "Nap — 35m".
+func (c *Calendar) AddSleep(s *whoop.Sleep) {
+	kind := "Sleep"
+	if s.Nap {
+		kind = "Nap"
+	}
+	ev := icsEvent{
+		uid:      "sleep-" + s.ID + "@" + icsUIDDomain,
+		stamp:    stampOf(s.UpdatedAt, s.CreatedAt, s.Start),
+		start:    s.Start,
+		end:      s.End,
+		summary:  kind + " — " + formatHoursMinutes(s.Duration()),
+		category: kind,
+	}
+	if s.IsScored() {
+		sc := s.Score
+		if st := sc.StageSummary; st != nil {
+			ev.summary = fmt.Sprintf("%s — %s, %.0f%%", kind, formatHoursMinutes(st.TotalSleepTime()), sc.SleepPerformancePercentage)
+			ev.description = append(ev.description,
+				"Asleep: "+formatHoursMinutes(st.TotalSleepTime()),
+				fmt.Sprintf("Light: %s, SWS: %s, REM: %s, Awake: %s",
+					formatHoursMinutes(st.TotalLightSleepTime()), formatHoursMinutes(st.TotalSlowWaveSleepTime()),
+					formatHoursMinutes(st.TotalRemSleepTime()), formatHoursMinutes(st.TotalAwakeTime())),
+				fmt.Sprintf("Disturbances: %d", st.DisturbanceCount),
+			)
+		}
+		ev.description = append(ev.description,
+			fmt.Sprintf("Performance: %.0f%%", sc.SleepPerformancePercentage),
+			fmt.Sprintf("Efficiency: %.0f%%", sc.SleepEfficiencyPercentage),
+			fmt.Sprintf("Respiratory rate: %.1f", sc.RespiratoryRate),
+		)
+	}
+	c.add(ev, s.TimezoneOffset)
+}
+
+// AddWorkout adds an event for w, such as "Running — strain 12.4".
+func (c *Calendar) AddWorkout(w *whoop.Workout) {
+	sport := w.Sport().Name
+	if sport == "" {
+		sport = "Workout"
+	}
+	ev := icsEvent{
+		uid:         "workout-" + w.ID + "@" + icsUIDDomain,
+		stamp:       stampOf(w.UpdatedAt, w.CreatedAt, w.Start),
+		start:       w.Start,
+		end:         w.End,
+		summary:     sport,
+		description: []string{"Duration: " + formatHoursMinutes(w.Duration())},
+		category:    "Workout",
+	}
+	if w.IsScored() {
+		s := w.Score
+		ev.summary = fmt.Sprintf("%s — strain %.1f", sport, s.Strain)
+		ev.description = append(ev.description,
+			fmt.Sprintf("Strain: %.1f", s.Strain),
+			fmt.Sprintf("Heart rate: %d avg, %d max", s.AverageHeartRate, s.MaxHeartRate),
+			fmt.Sprintf("Energy: %.0f %s", c.opts.Energy.convert(s.Energy()), energyLabel(c.opts.Energy)),
+		)
+		if d, ok := s.Distance(); ok {
+			ev.description = append(ev.description, fmt.Sprintf("Distance: %.2f %s", c.opts.Distance.convert(d), c.opts.Distance.suffix()))
+		}
+	}
+	c.add(ev, w.TimezoneOffset)
+}
+
+func (c *Calendar) add(ev icsEvent, offset string) {
+	if loc, err := whoop.ParseTimezoneOffset(offset); err == nil {
+		_, secs := time.Unix(0, 0).In(loc).Zone()
+		ev.tzid = tzidFor(secs)
+		c.zones[ev.tzid] = secs
+	}
+	c.events = append(c.events, ev)
+}
+
+// WriteTo writes the calendar to w with CRLF line endings, events ordered by
+// start time.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b icsBuilder
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	b.line("PRODID:" + icsProdID)
+	b.line("CALSCALE:GREGORIAN")
+	b.line("METHOD:PUBLISH")
+	if c.opts.Name != "" {
+		b.line("X-WR-CALNAME:" + escapeText(c.opts.Name))
+	}
+
+	tzids := make([]string, 0, len(c.zones))
+	for tzid := range c.zones {
+		tzids = append(tzids, tzid)
+	}
+	slices.SortFunc(tzids, func(a, b string) int { return cmp.Compare(c.zones[a], c.zones[b]) })
+	for _, tzid := range tzids {
+		offset := formatUTCOffset(c.zones[tzid])
+		b.line("BEGIN:VTIMEZONE")
+		b.line("TZID:" + tzid)
+		b.line("BEGIN:STANDARD")
+		b.line("DTSTART:19700101T000000")
+		b.line("TZOFFSETFROM:" + offset)
+		b.line("TZOFFSETTO:" + offset)
+		b.line("TZNAME:" + tzid)
+		b.line("END:STANDARD")
+		b.line("END:VTIMEZONE")
+	}
+
+	events := slices.Clone(c.events)
+	slices.SortStableFunc(events, func(a, b icsEvent) int { return a.start.Compare(b.start) })
+	for _, ev := range events {
+		b.line("BEGIN:VEVENT")
+		b.line("UID:" + escapeText(ev.uid))
+		b.line("DTSTAMP:" + ev.stamp.UTC().Format(icsUTCLayout))
+		b.line(dateTimeProperty("DTSTART", ev.start, ev.tzid, c.zones[ev.tzid]))
+		b.line(dateTimeProperty("DTEND", ev.end, ev.tzid, c.zones[ev.tzid]))
+		b.line("SUMMARY:" + escapeText(ev.summary))
+		if len(ev.description) > 0 {
+			b.line("DESCRIPTION:" + escapeText(strings.Join(ev.description, "\n")))
+		}
+		b.line("CATEGORIES:WHOOP," + escapeText(ev.category))
+		b.line("TRANSP:TRANSPARENT")
+		b.line("END:VEVENT")
+	}
+	b.line("END:VCALENDAR")
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// icsBuilder accumulates content lines, folded at 75 octets as RFC 5545
+// requires without splitting UTF-8 sequences.
+type icsBuilder struct {
+	bytes.Buffer
+}
+
+func (b *icsBuilder) line(s string) {
+	limit := icsMaxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icsMaxLineOctets - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// textEscaper escapes TEXT property values.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// dateTimeProperty formats a DTSTART or DTEND property in the zone tzid, or
+// in UTC when tzid is empty.
+func dateTimeProperty(name string, t time.Time, tzid string, offset int) string {
+	if tzid == "" {
+		return name + ":" + t.UTC().Format(icsUTCLayout)
+	}
+	return name + ";TZID=" + tzid + ":" + t.In(time.FixedZone(tzid, offset)).Format(icsLocalLayout)
+}
+
+// tzidFor names the fixed zone with the given offset, such as "UTC-0800".
+func tzidFor(offset int) string {
+	return "UTC" + formatUTCOffset(offset)
+}
+
+// formatUTCOffset formats an offset in seconds as ±HHMM.
+func formatUTCOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// stampOf returns the first non-zero time, used as DTSTAMP so that an
+// unchanged record always produces an identical event.
+func stampOf(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Unix(0, 0)
+}
+
+// formatHoursMinutes formats d as "7h 42m", or "35m" under an hour.
+func formatHoursMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %02dm", h, m)
+}
+
+func energyLabel(u EnergyUnit) string {
+	if u == Kilocalories {
+		return "kcal"
+	}
+	return "kJ"
+}
+
+// calendarFeed serves recent sleeps and workouts as a calendar.
+type calendarFeed struct {
+	client   *whoop.Client
+	lookback time.Duration
+	opts     ICSOptions
+}
+
+// NewCalendarFeed returns an http.Handler that serves the sleeps and workouts
+// of the last lookback as a text/calendar feed that calendar applications can
+// subscribe to. Records are fetched from the API on every request. Failures
+// to fetch are reported as 502 Bad Gateway.
+func NewCalendarFeed(client *whoop.Client, lookback time.Duration, opts ICSOptions) http.Handler {
+	if lookback <= 0 {
+		lookback = DefaultFeedLookback
+	}
+	return &calendarFeed{client: client, lookback: lookback, opts: opts}
+}
+
+func (f *calendarFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cal, err := f.build(r.Context())
+	if err != nil {
+		http.Error(w, "failed to fetch WHOOP records", http.StatusBadGateway)
+		return
+	}
+
+	var buf bytes.Buffer
+	if _, err := cal.WriteTo(&buf); err != nil {
+		http.Error(w, "failed to write calendar", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (f *calendarFeed) build(ctx context.Context) (*Calendar, error) {
+	start := time.Now().Add(-f.lookback)
+	opts := &whoop.ListOptions{Start: &start, Limit: 25}
+	cal := NewCalendar(f.opts)
+
+	sleeps, err := f.client.Sleep.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := eachRecord(ctx, sleeps, func(p *whoop.SleepPage) []whoop.Sleep { return p.Records }, (*whoop.SleepPage).NextPage, func(s *whoop.Sleep) error {
+		cal.AddSleep(s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	workouts, err := f.client.Workout.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := eachRecord(ctx, workouts, func(p *whoop.WorkoutPage) []whoop.Workout { return p.Records }, (*whoop.WorkoutPage).NextPage, func(w *whoop.Workout) error {
+		cal.AddWorkout(w)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
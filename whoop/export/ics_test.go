@@ -0,0 +1,209 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// unfold reverses RFC 5545 line folding and splits the output into lines.
+func unfold(t *testing.T, data string) []string {
+	t.Helper()
+	if !strings.HasSuffix(data, "\r\n") {
+		t.Fatal("expected output to end with CRLF")
+	}
+	for _, line := range strings.Split(data, "\r\n") {
+		if len(line) > icsMaxLineOctets {
+			t.Errorf("line longer than %d octets: %q", icsMaxLineOctets, line)
+		}
+		if strings.Contains(line, "\n") {
+			t.Errorf("bare LF in line %q", line)
+		}
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(data, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestCalendar(t *testing.T) {
+	start := time.Date(2026, 2, 24, 6, 30, 0, 0, time.UTC)
+	distance := 10000.0
+
+	cal := NewCalendar(ICSOptions{Name: "Alice, WHOOP", Energy: Kilocalories, Distance: Kilometers})
+	cal.AddWorkout(&whoop.Workout{
+		ID: "w1", Start: start.Add(10 * time.Hour), End: start.Add(11 * time.Hour), TimezoneOffset: "+05:30",
+		UpdatedAt: start.Add(12 * time.Hour), SportID: 0, ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.WorkoutScore{Strain: 12.44, AverageHeartRate: 150, MaxHeartRate: 181, Kilojoule: 2092, DistanceMeter: &distance},
+	})
+	cal.AddSleep(&whoop.Sleep{
+		ID: "s1", Start: start.Add(-8 * time.Hour), End: start, TimezoneOffset: "-08:00",
+		UpdatedAt: start.Add(time.Hour), ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.SleepScore{
+			SleepPerformancePercentage: 91, SleepEfficiencyPercentage: 94, RespiratoryRate: 15.2,
+			StageSummary: &whoop.StageSummary{
+				TotalInBedTimeMilli: 28800000, TotalAwakeTimeMilli: 1080000, TotalLightSleepTimeMilli: 14400000,
+				TotalSlowWaveSleepTimeMilli: 5400000, TotalRemSleepTimeMilli: 7920000, DisturbanceCount: 3,
+			},
+		},
+	})
+	cal.AddSleep(&whoop.Sleep{ID: "s2", Start: start.Add(5 * time.Hour), End: start.Add(5*time.Hour + 35*time.Minute), TimezoneOffset: "bogus", Nap: true})
+	if cal.Len() != 3 {
+		t.Fatalf("expected 3 events, got %d", cal.Len())
+	}
+
+	var buf bytes.Buffer
+	if _, err := cal.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := unfold(t, buf.String())
+
+	want := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//arvarik//whoop-go//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Alice\, WHOOP`,
+		"BEGIN:VTIMEZONE",
+		"TZID:UTC-0800",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:-0800",
+		"TZOFFSETTO:-0800",
+		"TZNAME:UTC-0800",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VTIMEZONE",
+		"TZID:UTC+0530",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:+0530",
+		"TZOFFSETTO:+0530",
+		"TZNAME:UTC+0530",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:sleep-s1@whoop-go",
+		"DTSTAMP:20260224T073000Z",
+		"DTSTART;TZID=UTC-0800:20260223T143000",
+		"DTEND;TZID=UTC-0800:20260223T223000",
+		"SUMMARY:Sleep — 7h 42m\\, 91%",
+		`DESCRIPTION:Asleep: 7h 42m\nLight: 4h 00m\, SWS: 1h 30m\, REM: 2h 12m\, Awake: 18m\nDisturbances: 3\nPerformance: 91%\nEfficiency: 94%\nRespiratory rate: 15.2`,
+		"CATEGORIES:WHOOP,Sleep",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sleep-s2@whoop-go",
+		"DTSTAMP:20260224T113000Z",
+		"DTSTART:20260224T113000Z",
+		"DTEND:20260224T120500Z",
+		"SUMMARY:Nap — 35m",
+		"CATEGORIES:WHOOP,Nap",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:workout-w1@whoop-go",
+		"DTSTAMP:20260224T183000Z",
+		"DTSTART;TZID=UTC+0530:20260224T220000",
+		"DTEND;TZID=UTC+0530:20260224T230000",
+		"SUMMARY:Running — strain 12.4",
+		`DESCRIPTION:Duration: 1h 00m\nStrain: 12.4\nHeart rate: 150 avg\, 181 max\nEnergy: 500 kcal\nDistance: 10.00 km`,
+		"CATEGORIES:WHOOP,Workout",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(lines), strings.Join(lines, "\n"))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i, lines[i], want[i])
+		}
+	}
+
+	// The same records produce identical output, so re-imports are no-ops.
+	var again bytes.Buffer
+	if _, err := cal.WriteTo(&again); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.String() != buf.String() {
+		t.Error("expected repeated writes to be identical")
+	}
+}
+
+func TestICSBuilder_Folding(t *testing.T) {
+	var b icsBuilder
+	long := "SUMMARY:" + strings.Repeat("é", 100) // two octets per rune
+	b.line(long)
+
+	folded := b.String()
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(line) > icsMaxLineOctets {
+			t.Errorf("line longer than %d octets: %d", icsMaxLineOctets, len(line))
+		}
+		if !strings.HasPrefix(line, "SUMMARY:") && !strings.HasPrefix(line, " é") {
+			t.Errorf("expected folds between runes, got %q", line)
+		}
+	}
+	if got := strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""); got != long {
+		t.Errorf("expected unfolding to restore the line, got %q", got)
+	}
+}
+
+func TestCalendarFeed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/activity/sleep", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") == "" {
+			t.Error("expected a start parameter")
+		}
+		if r.URL.Query().Get("nextToken") == "" {
+			_, _ = w.Write([]byte(`{"records":[{"id":"s1","start":"2026-02-24T06:00:00Z","end":"2026-02-24T07:00:00Z","timezone_offset":"-08:00","score_state":"PENDING_SCORE"}],"next_token":"more"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records":[{"id":"s2","start":"2026-02-23T06:00:00Z","end":"2026-02-23T07:00:00Z","timezone_offset":"-08:00","score_state":"PENDING_SCORE"}]}`))
+	})
+	mux.HandleFunc("/activity/workout", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"records":[{"id":"w1","start":"2026-02-24T16:00:00Z","end":"2026-02-24T17:00:00Z","timezone_offset":"-08:00","sport_id":1,"score_state":"PENDING_SCORE"}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := whoop.NewClient(whoop.WithBaseURL(ts.URL), whoop.WithRateLimiting(false))
+	feed := NewCalendarFeed(client, 0, ICSOptions{Name: "WHOOP"})
+
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whoop.ics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/calendar; charset=utf-8" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	body := rec.Body.String()
+	for _, uid := range []string{"UID:sleep-s1@whoop-go", "UID:sleep-s2@whoop-go", "UID:workout-w1@whoop-go"} {
+		if !strings.Contains(body, uid+"\r\n") {
+			t.Errorf("expected %s in feed", uid)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/whoop.ics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", rec.Code)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	feed = NewCalendarFeed(whoop.NewClient(whoop.WithBaseURL(failing.URL), whoop.WithRateLimiting(false)), time.Hour, ICSOptions{})
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whoop.ics", nil))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502 when the API fails, got %d", rec.Code)
+	}
+}
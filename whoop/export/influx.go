@@ -0,0 +1,334 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// Measurement names written by LineWriter.
+const (
+	MeasurementCycle    = "whoop_cycle"
+	MeasurementSleep    = "whoop_sleep"
+	MeasurementWorkout  = "whoop_workout"
+	MeasurementRecovery = "whoop_recovery"
+)
+
+// maxInfluxErrorBody caps how much of an error response is kept in the error.
+const maxInfluxErrorBody = 1000
+
+// LineOptions configures a LineWriter.
+type LineOptions struct {
+	// User is the value of the user tag. When empty, each record's UserID is
+	// used.
+	User string
+}
+
+// LineWriter streams records as InfluxDB line protocol, one point per record,
+// timestamped in milliseconds (the resolution of WHOOP timestamps) at the
+// record's start. Recoveries have no start and are timestamped at CreatedAt.
+//
+// Every point is tagged with user and score_state; sleeps add nap and
+// workouts add sport. Unscored records carry only their identifying and
+// timing fields. A LineWriter is not safe for concurrent use.
+type LineWriter struct {
+	w    *bufio.Writer
+	opts LineOptions
+}
+
+// NewLineWriter returns a LineWriter that writes to w.
+func NewLineWriter(w io.Writer, opts LineOptions) *LineWriter {
+	return &LineWriter{w: bufio.NewWriter(w), opts: opts}
+}
+
+// WriteCycle writes a whoop_cycle point.
+func (lw *LineWriter) WriteCycle(c *whoop.Cycle) error {
+	p := lw.point(MeasurementCycle, c.UserID, c.ScoreState, c.Start)
+	p.intField("id", c.ID)
+	if c.End != nil {
+		p.floatField("duration_seconds", c.End.Sub(c.Start).Seconds())
+	}
+	if s := c.Score; s != nil {
+		p.floatField("strain", s.Strain)
+		p.floatField("kilojoules", s.Kilojoule)
+		p.intField("average_heart_rate", s.AverageHeartRate)
+		p.intField("max_heart_rate", s.MaxHeartRate)
+	}
+	return lw.write(p)
+}
+
+// WriteSleep writes a whoop_sleep point.
+func (lw *LineWriter) WriteSleep(s *whoop.Sleep) error {
+	p := lw.point(MeasurementSleep, s.UserID, s.ScoreState, s.Start)
+	p.tag("nap", strconv.FormatBool(s.Nap))
+	p.stringField("id", s.ID)
+	p.intField("cycle_id", s.CycleID)
+	p.floatField("duration_seconds", s.Duration().Seconds())
+	if sc := s.Score; sc != nil {
+		p.floatField("respiratory_rate", sc.RespiratoryRate)
+		p.floatField("sleep_performance_percentage", sc.SleepPerformancePercentage)
+		p.floatField("sleep_consistency_percentage", sc.SleepConsistencyPercentage)
+		p.floatField("sleep_efficiency_percentage", sc.SleepEfficiencyPercentage)
+		if st := sc.StageSummary; st != nil {
+			p.floatField("in_bed_seconds", st.TotalInBedTime().Seconds())
+			p.floatField("awake_seconds", st.TotalAwakeTime().Seconds())
+			p.floatField("no_data_seconds", st.TotalNoDataTime().Seconds())
+			p.floatField("light_seconds", st.TotalLightSleepTime().Seconds())
+			p.floatField("slow_wave_seconds", st.TotalSlowWaveSleepTime().Seconds())
+			p.floatField("rem_seconds", st.TotalRemSleepTime().Seconds())
+			p.floatField("asleep_seconds", st.TotalSleepTime().Seconds())
+			p.intField("sleep_cycle_count", st.SleepCycleCount)
+			p.intField("disturbance_count", st.DisturbanceCount)
+		}
+		if n := sc.SleepNeeded; n != nil {
+			p.floatField("sleep_need_seconds", n.Total().Seconds())
+		}
+	}
+	return lw.write(p)
+}
+
+// WriteWorkout writes a whoop_workout point.
+func (lw *LineWriter) WriteWorkout(w *whoop.Workout) error {
+	p := lw.point(MeasurementWorkout, w.UserID, w.ScoreState, w.Start)
+	p.tag("sport", w.Sport().Name)
+	p.stringField("id", w.ID)
+	p.intField("sport_id", w.SportID)
+	p.floatField("duration_seconds", w.Duration().Seconds())
+	if s := w.Score; s != nil {
+		p.floatField("strain", s.Strain)
+		p.intField("average_heart_rate", s.AverageHeartRate)
+		p.intField("max_heart_rate", s.MaxHeartRate)
+		p.floatField("kilojoules", s.Kilojoule)
+		p.floatField("percent_recorded", s.PercentRecorded)
+		if d, ok := s.Distance(); ok {
+			p.floatField("distance_meters", d.Meters())
+		}
+		if d, ok := s.AltitudeGain(); ok {
+			p.floatField("altitude_gain_meters", d.Meters())
+		}
+		if d, ok := s.AltitudeChange(); ok {
+			p.floatField("altitude_change_meters", d.Meters())
+		}
+		if z := s.ZoneDuration; z != nil {
+			for i, d := range []time.Duration{z.ZoneZero(), z.ZoneOne(), z.ZoneTwo(), z.ZoneThree(), z.ZoneFour(), z.ZoneFive()} {
+				p.floatField("zone_"+strconv.Itoa(i)+"_seconds", d.Seconds())
+			}
+		}
+	}
+	return lw.write(p)
+}
+
+// WriteRecovery writes a whoop_recovery point.
+func (lw *LineWriter) WriteRecovery(r *whoop.Recovery) error {
+	p := lw.point(MeasurementRecovery, r.UserID, r.ScoreState, r.CreatedAt)
+	p.intField("cycle_id", r.CycleID)
+	p.stringField("sleep_id", r.SleepID)
+	if s := r.Score; s != nil {
+		p.boolField("user_calibrating", s.UserCalibrating)
+		p.floatField("recovery_score", s.RecoveryScore)
+		p.floatField("resting_heart_rate", s.RestingHeartRate)
+		p.floatField("hrv_rmssd_milli", s.HrvRmssdMilli)
+		p.floatField("spo2_percentage", s.Spo2Percentage)
+		p.floatField("skin_temp_celsius", s.SkinTempCelsius)
+	}
+	return lw.write(p)
+}
+
+// Flush flushes any buffered lines to the underlying writer.
+func (lw *LineWriter) Flush() error {
+	return lw.w.Flush()
+}
+
+func (lw *LineWriter) point(measurement string, userID int, state whoop.ScoreState, ts time.Time) *point {
+	user := lw.opts.User
+	if user == "" {
+		user = strconv.Itoa(userID)
+	}
+	p := &point{measurement: measurement, time: ts}
+	p.tag("user", user)
+	p.tag("score_state", string(state))
+	return p
+}
+
+func (lw *LineWriter) write(p *point) error {
+	_, err := lw.w.WriteString(p.line())
+	return err
+}
+
+// point is one line of line protocol. Tag and field values are stored
+// already encoded.
+type point struct {
+	measurement string
+	tags        [][2]string
+	fields      [][2]string
+	time        time.Time
+}
+
+// tag adds a tag. Empty values are not allowed by line protocol and are
+// skipped.
+func (p *point) tag(key, value string) {
+	if value != "" {
+		p.tags = append(p.tags, [2]string{key, value})
+	}
+}
+
+// floatField adds a float field. NaN and infinities cannot be represented and
+// are skipped.
+func (p *point) floatField(key string, v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return
+	}
+	p.fields = append(p.fields, [2]string{key, strconv.FormatFloat(v, 'f', -1, 64)})
+}
+
+func (p *point) intField(key string, v int) {
+	p.fields = append(p.fields, [2]string{key, strconv.Itoa(v) + "i"})
+}
+
+func (p *point) boolField(key string, v bool) {
+	p.fields = append(p.fields, [2]string{key, strconv.FormatBool(v)})
+}
+
+func (p *point) stringField(key, v string) {
+	p.fields = append(p.fields, [2]string{key, `"` + fieldValueEscaper.Replace(v) + `"`})
+}
+
+// line encodes the point, with tags sorted by key as InfluxDB recommends.
+func (p *point) line() string {
+	slices.SortFunc(p.tags, func(a, b [2]string) int { return strings.Compare(a[0], b[0]) })
+
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(p.measurement))
+	for _, t := range p.tags {
+		b.WriteByte(',')
+		b.WriteString(keyEscaper.Replace(t[0]))
+		b.WriteByte('=')
+		b.WriteString(keyEscaper.Replace(t[1]))
+	}
+	for i, f := range p.fields {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(keyEscaper.Replace(f[0]))
+		b.WriteByte('=')
+		b.WriteString(f[1])
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatInt(p.time.UnixMilli(), 10))
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// Line protocol escaping. Newlines cannot be escaped and are replaced with
+// spaces (escaped where spaces are significant).
+var (
+	measurementEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, " ", `\ `, "\n", `\ `)
+	keyEscaper         = strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`, " ", `\ `, "\n", `\ `)
+	fieldValueEscaper  = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ")
+)
+
+// InfluxConfig locates an InfluxDB v2 write endpoint.
+type InfluxConfig struct {
+	// URL is the base URL of the server, such as "http://localhost:8086".
+	URL string
+
+	Org    string
+	Bucket string
+	Token  string
+
+	// HTTPClient is used for requests. nil uses a client with a 30 second
+	// timeout, like whoop.NewClient.
+	HTTPClient *http.Client
+}
+
+// defaultInfluxClient is used when InfluxConfig.HTTPClient is nil.
+var defaultInfluxClient = &http.Client{Timeout: 30 * time.Second}
+
+// InfluxWriter buffers line protocol written to it, typically by a
+// LineWriter, and sends it to an InfluxDB v2 /api/v2/write endpoint with
+// millisecond precision when Send is called. An InfluxWriter is not safe for
+// concurrent use.
+//
+//	iw := export.NewInfluxWriter(export.InfluxConfig{URL: "http://localhost:8086", Org: "home", Bucket: "whoop", Token: token})
+//	lw := export.NewLineWriter(iw, export.LineOptions{User: "alice"})
+//	for i := range page.Records {
+//	    lw.WriteSleep(&page.Records[i])
+//	}
+//	if err := lw.Flush(); err != nil {
+//	    return err
+//	}
+//	return iw.Send(ctx)
+type InfluxWriter struct {
+	cfg InfluxConfig
+	buf bytes.Buffer
+}
+
+// NewInfluxWriter returns an InfluxWriter for the endpoint described by cfg.
+func NewInfluxWriter(cfg InfluxConfig) *InfluxWriter {
+	return &InfluxWriter{cfg: cfg}
+}
+
+// Write buffers p. It never fails.
+func (w *InfluxWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Len returns the number of buffered bytes not yet sent.
+func (w *InfluxWriter) Len() int {
+	return w.buf.Len()
+}
+
+// Send posts the buffered lines in one request. The buffer is cleared only
+// when the server accepts them, so a failed Send can be retried. Sending an
+// empty buffer is a no-op.
+func (w *InfluxWriter) Send(ctx context.Context) error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	query := url.Values{}
+	query.Set("org", w.cfg.Org)
+	query.Set("bucket", w.cfg.Bucket)
+	query.Set("precision", "ms")
+	endpoint := strings.TrimSuffix(w.cfg.URL, "/") + "/api/v2/write?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(w.buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("failed to create influx write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.cfg.Token != "" {
+		req.Header.Set("Authorization", "Token "+w.cfg.Token)
+	}
+
+	httpClient := w.cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultInfluxClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("influx write failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxInfluxErrorBody))
+		return fmt.Errorf("influx write failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	w.buf.Reset()
+	return nil
+}
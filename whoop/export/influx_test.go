@@ -0,0 +1,153 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+func TestLineWriter(t *testing.T) {
+	start := time.Date(2026, 2, 24, 5, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	distance := 5000.0
+
+	var buf bytes.Buffer
+	lw := NewLineWriter(&buf, LineOptions{})
+	for _, write := range []func() error{
+		func() error {
+			return lw.WriteCycle(&whoop.Cycle{
+				ID: 1, UserID: 9, Start: start, End: &end, ScoreState: whoop.ScoreStateScored,
+				Score: &whoop.Score{Strain: 12.4, Kilojoule: 9000, AverageHeartRate: 65, MaxHeartRate: 180},
+			})
+		},
+		func() error {
+			return lw.WriteSleep(&whoop.Sleep{
+				ID: "s1", CycleID: 1, UserID: 9, Start: start, End: start.Add(8 * time.Hour), Nap: true,
+				ScoreState: whoop.ScoreStatePendingScore,
+			})
+		},
+		func() error {
+			return lw.WriteWorkout(&whoop.Workout{
+				ID: "w1", UserID: 9, Start: start, End: start.Add(30 * time.Minute), SportID: 0,
+				ScoreState: whoop.ScoreStateScored,
+				Score: &whoop.WorkoutScore{
+					Strain: 8.5, AverageHeartRate: 150, MaxHeartRate: 175, Kilojoule: 1200, PercentRecorded: 100,
+					DistanceMeter: &distance, ZoneDuration: &whoop.ZoneDurations{ZoneTwoMilli: 600000},
+				},
+			})
+		},
+		func() error {
+			return lw.WriteRecovery(&whoop.Recovery{
+				CycleID: 1, SleepID: "s1", UserID: 9, CreatedAt: end, ScoreState: whoop.ScoreStateScored,
+				Score: &whoop.RecoveryScore{RecoveryScore: 66, RestingHeartRate: 52, HrvRmssdMilli: 48.5, Spo2Percentage: 96, SkinTempCelsius: 33.7},
+			})
+		},
+	} {
+		if err := write(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Error("expected lines to be buffered until Flush")
+	}
+	if err := lw.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`whoop_cycle,score_state=SCORED,user=9 id=1i,duration_seconds=86400,strain=12.4,kilojoules=9000,average_heart_rate=65i,max_heart_rate=180i 1771909200000`,
+		`whoop_sleep,nap=true,score_state=PENDING_SCORE,user=9 id="s1",cycle_id=1i,duration_seconds=28800 1771909200000`,
+		`whoop_workout,score_state=SCORED,sport=Running,user=9 id="w1",sport_id=0i,duration_seconds=1800,strain=8.5,average_heart_rate=150i,max_heart_rate=175i,kilojoules=1200,percent_recorded=100,distance_meters=5000,` +
+			`zone_0_seconds=0,zone_1_seconds=0,zone_2_seconds=600,zone_3_seconds=0,zone_4_seconds=0,zone_5_seconds=0 1771909200000`,
+		`whoop_recovery,score_state=SCORED,user=9 cycle_id=1i,sleep_id="s1",user_calibrating=false,recovery_score=66,resting_heart_rate=52,hrv_rmssd_milli=48.5,spo2_percentage=96,skin_temp_celsius=33.7 1771995600000`,
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(got), buf.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLineWriter_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+	lw := NewLineWriter(&buf, LineOptions{User: "a b,c=d"})
+	w := &whoop.Workout{ID: "x\"y\\z", SportID: -42, SportName: "Made Up", ScoreState: whoop.ScoreStateUnscorable}
+	if err := lw.WriteWorkout(w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := lw.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `whoop_workout,score_state=UNSCORABLE,sport=Made\ Up,user=a\ b\,c\=d id="x\"y\\z",sport_id=-42i,duration_seconds=0 -62135596800000` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestInfluxWriter_Send(t *testing.T) {
+	var gotBody, gotQuery, gotAuth string
+	status := http.StatusNoContent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/write" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotBody, gotQuery, gotAuth = string(body), r.URL.RawQuery, r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			_, _ = w.Write([]byte(`{"code":"invalid","message":"bad line"}`))
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	iw := NewInfluxWriter(InfluxConfig{URL: ts.URL + "/", Org: "home", Bucket: "whoop", Token: "secret"})
+	if err := iw.Send(ctx); err != nil || gotBody != "" {
+		t.Fatalf("expected an empty Send to do nothing, got %v", err)
+	}
+
+	lw := NewLineWriter(iw, LineOptions{User: "alice"})
+	if err := lw.WriteRecovery(&whoop.Recovery{CycleID: 1, ScoreState: whoop.ScoreStatePendingScore}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := lw.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status = http.StatusBadRequest
+	err := iw.Send(ctx)
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "bad line") {
+		t.Fatalf("expected a status error with the response body, got %v", err)
+	}
+	if iw.Len() == 0 {
+		t.Fatal("expected the buffer to be kept after a failed send")
+	}
+
+	status = http.StatusNoContent
+	if err := iw.Send(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "bucket=whoop&org=home&precision=ms" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if gotAuth != "Token secret" {
+		t.Errorf("unexpected authorization %q", gotAuth)
+	}
+	if !strings.HasPrefix(gotBody, "whoop_recovery,score_state=PENDING_SCORE,user=alice cycle_id=1i,") {
+		t.Errorf("unexpected body %q", gotBody)
+	}
+	if iw.Len() != 0 {
+		t.Error("expected the buffer to be cleared after a successful send")
+	}
+}
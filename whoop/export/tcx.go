@@ -0,0 +1,126 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// tcxNamespace is the Training Center Database v2 schema namespace.
+const tcxNamespace = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+
+type tcxDatabase struct {
+	XMLName    xml.Name      `xml:"TrainingCenterDatabase"`
+	Namespace  string        `xml:"xmlns,attr"`
+	Activities []tcxActivity `xml:"Activities>Activity"`
+}
+
+type tcxActivity struct {
+	Sport string `xml:"Sport,attr"`
+	ID    string `xml:"Id"`
+	Lap   tcxLap `xml:"Lap"`
+	Notes string `xml:"Notes,omitempty"`
+}
+
+type tcxLap struct {
+	StartTime        string        `xml:"StartTime,attr"`
+	TotalTimeSeconds string        `xml:"TotalTimeSeconds"`
+	DistanceMeters   string        `xml:"DistanceMeters"`
+	Calories         int           `xml:"Calories"`
+	AverageHeartRate *tcxHeartRate `xml:"AverageHeartRateBpm,omitempty"`
+	MaximumHeartRate *tcxHeartRate `xml:"MaximumHeartRateBpm,omitempty"`
+	Intensity        string        `xml:"Intensity"`
+	TriggerMethod    string        `xml:"TriggerMethod"`
+	Track            []tcxPoint    `xml:"Track>Trackpoint"`
+}
+
+type tcxHeartRate struct {
+	Value int `xml:"Value"`
+}
+
+type tcxPoint struct {
+	Time           string `xml:"Time"`
+	DistanceMeters string `xml:"DistanceMeters,omitempty"`
+}
+
+// tcxSport returns the TCX sport for a WHOOP sport ID. TCX only knows
+// running and biking; every other sport is "Other".
+func tcxSport(sportID int) string {
+	switch FITSport(sportID) {
+	case FITSportRunning:
+		return "Running"
+	case FITSportCycling:
+		return "Biking"
+	default:
+		return "Other"
+	}
+}
+
+// WriteTCX writes w as a Training Center XML activity with a single lap
+// summarizing the workout: elapsed time, distance, calories and average and
+// maximum heart rate. The track holds only the start and end points, since
+// the API provides no samples. TCX has no field for the sport's name, strain
+// or altitude, so they are written to the activity's notes.
+func WriteTCX(out io.Writer, w *whoop.Workout) error {
+	start := w.Start.UTC().Format(time.RFC3339)
+	lap := tcxLap{
+		StartTime:        start,
+		TotalTimeSeconds: formatFloat(max(w.Duration().Seconds(), 0)),
+		DistanceMeters:   "0",
+		Intensity:        "Active",
+		TriggerMethod:    "Manual",
+	}
+	notes := w.Sport().Name
+	end := tcxPoint{Time: w.End.UTC().Format(time.RFC3339)}
+
+	if w.IsScored() {
+		s := w.Score
+		lap.Calories = int(min(math.Round(s.Energy().Kilocalories()), math.MaxUint16))
+		if s.AverageHeartRate > 0 {
+			lap.AverageHeartRate = &tcxHeartRate{s.AverageHeartRate}
+		}
+		if s.MaxHeartRate > 0 {
+			lap.MaximumHeartRate = &tcxHeartRate{s.MaxHeartRate}
+		}
+		if d, ok := s.Distance(); ok {
+			lap.DistanceMeters = formatFloat(d.Meters())
+			end.DistanceMeters = lap.DistanceMeters
+		}
+		notes += fmt.Sprintf(", strain %.1f", s.Strain)
+		if d, ok := s.AltitudeGain(); ok {
+			notes += fmt.Sprintf(", altitude gain %.0f m", d.Meters())
+		}
+		if d, ok := s.AltitudeChange(); ok {
+			notes += fmt.Sprintf(", altitude change %.0f m", d.Meters())
+		}
+	}
+	lap.Track = []tcxPoint{{Time: start, DistanceMeters: "0"}, end}
+	if end.DistanceMeters == "" {
+		lap.Track[0].DistanceMeters = ""
+	}
+
+	doc := tcxDatabase{
+		Namespace: tcxNamespace,
+		Activities: []tcxActivity{{
+			Sport: tcxSport(w.SportID),
+			ID:    start,
+			Lap:   lap,
+			Notes: notes,
+		}},
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
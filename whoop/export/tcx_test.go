@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+func TestWriteTCX_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTCX(&buf, goldenWorkout()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkGolden(t, "workout.tcx", buf.Bytes())
+
+	var doc tcxDatabase
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if len(doc.Activities) != 1 || doc.Activities[0].Sport != "Running" || doc.Activities[0].Lap.Calories != 693 {
+		t.Errorf("unexpected activity %+v", doc.Activities)
+	}
+}
+
+func TestWriteTCX_Unscored(t *testing.T) {
+	start := time.Date(2026, 2, 24, 14, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := WriteTCX(&buf, &whoop.Workout{SportID: 44, Start: start, End: start.Add(time.Hour), ScoreState: whoop.ScoreStateUnscorable})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<Activity Sport="Other">`,
+		"<TotalTimeSeconds>3600</TotalTimeSeconds>",
+		"<Calories>0</Calories>",
+		"<Notes>Yoga</Notes>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "HeartRateBpm") {
+		t.Error("expected no heart rate for an unscored workout")
+	}
+}
+
+func TestTCXSport(t *testing.T) {
+	for id, want := range map[int]string{0: "Running", 1: "Biking", 57: "Biking", 63: "Other", 9999: "Other"} {
+		if got := tcxSport(id); got != want {
+			t.Errorf("tcxSport(%d) = %q, want %q", id, got, want)
+		}
+	}
+}
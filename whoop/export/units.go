@@ -0,0 +1,135 @@
+package export
+
+import (
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// EnergyUnit selects the unit of energy columns.
+type EnergyUnit int
+
+const (
+	// Kilojoules writes energy as reported by the API. Columns end in "_kj".
+	Kilojoules EnergyUnit = iota
+	// Kilocalories writes energy in dietary calories. Columns end in "_kcal".
+	Kilocalories
+)
+
+func (u EnergyUnit) suffix() string {
+	if u == Kilocalories {
+		return "kcal"
+	}
+	return "kj"
+}
+
+func (u EnergyUnit) convert(e whoop.Energy) float64 {
+	if u == Kilocalories {
+		return e.Kilocalories()
+	}
+	return e.Kilojoules()
+}
+
+// DistanceUnit selects the unit of distance and altitude columns.
+type DistanceUnit int
+
+const (
+	// Meters writes distances as reported by the API. Columns end in "_m".
+	Meters DistanceUnit = iota
+	// Kilometers writes distances in kilometers. Columns end in "_km".
+	Kilometers
+	// Miles writes distances in statute miles. Columns end in "_mi".
+	Miles
+	// Feet writes distances in feet, which suits altitude. Columns end in "_ft".
+	Feet
+)
+
+func (u DistanceUnit) suffix() string {
+	switch u {
+	case Kilometers:
+		return "km"
+	case Miles:
+		return "mi"
+	case Feet:
+		return "ft"
+	default:
+		return "m"
+	}
+}
+
+func (u DistanceUnit) convert(d whoop.Distance) float64 {
+	switch u {
+	case Kilometers:
+		return d.Kilometers()
+	case Miles:
+		return d.Miles()
+	case Feet:
+		return d.Feet()
+	default:
+		return d.Meters()
+	}
+}
+
+// TemperatureUnit selects the unit of temperature columns.
+type TemperatureUnit int
+
+const (
+	// Celsius writes temperatures as reported by the API. Columns end in "_c".
+	Celsius TemperatureUnit = iota
+	// Fahrenheit writes temperatures in degrees Fahrenheit. Columns end in "_f".
+	Fahrenheit
+)
+
+func (u TemperatureUnit) suffix() string {
+	if u == Fahrenheit {
+		return "f"
+	}
+	return "c"
+}
+
+func (u TemperatureUnit) convert(t whoop.Temperature) float64 {
+	if u == Fahrenheit {
+		return t.Fahrenheit()
+	}
+	return t.Celsius()
+}
+
+// DurationUnit selects the unit of duration columns.
+type DurationUnit int
+
+const (
+	// Milliseconds writes durations as reported by the API. Columns end in "_ms".
+	Milliseconds DurationUnit = iota
+	// Seconds writes durations in seconds. Columns end in "_s".
+	Seconds
+	// Minutes writes durations in minutes. Columns end in "_min".
+	Minutes
+	// Hours writes durations in hours. Columns end in "_h".
+	Hours
+)
+
+func (u DurationUnit) suffix() string {
+	switch u {
+	case Seconds:
+		return "s"
+	case Minutes:
+		return "min"
+	case Hours:
+		return "h"
+	default:
+		return "ms"
+	}
+}
+
+func (u DurationUnit) convert(d time.Duration) float64 {
+	switch u {
+	case Seconds:
+		return d.Seconds()
+	case Minutes:
+		return d.Minutes()
+	case Hours:
+		return d.Hours()
+	default:
+		return float64(d.Milliseconds())
+	}
+}
@@ -0,0 +1,161 @@
+package whoop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache maps a struct type to the lower-cased JSON names of its
+// fields. encoding/json matches names case-insensitively, so lookups must too.
+var knownFieldsCache sync.Map // reflect.Type -> map[string]bool
+
+// knownFields returns the lower-cased JSON field names of struct type t.
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	names := make(map[string]bool, t.NumField())
+	for f := range fieldsOf(t) {
+		names[strings.ToLower(f.name)] = true
+	}
+	knownFieldsCache.Store(t, names)
+	return names
+}
+
+// jsonField is an exported struct field and the name it has in JSON.
+type jsonField struct {
+	index int
+	name  string
+}
+
+// fieldsOf yields the exported fields of struct type t that take part in JSON
+// encoding, with their JSON names.
+func fieldsOf(t reflect.Type) iter.Seq[jsonField] {
+	return func(yield func(jsonField) bool) {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if !yield(jsonField{index: i, name: name}) {
+				return
+			}
+		}
+	}
+}
+
+// unmarshalExtra decodes data into v, a pointer to a struct type without its
+// own UnmarshalJSON method, and stores any object members that v's fields do
+// not define in extra. extra is set to nil when there are none.
+func unmarshalExtra(data []byte, v any, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := knownFields(reflect.TypeOf(v).Elem())
+	for name := range all {
+		if known[strings.ToLower(name)] {
+			delete(all, name)
+		}
+	}
+	if len(all) == 0 {
+		all = nil
+	}
+	*extra = all
+	return nil
+}
+
+// marshalExtra encodes v, a struct value whose type has no MarshalJSON method,
+// and appends the members of extra that v's fields do not define, in key order.
+func marshalExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if !known[strings.ToLower(k)] {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1]) // drop the closing brace
+	needComma := len(data) > 2
+	for _, k := range keys {
+		if needComma {
+			buf.WriteByte(',')
+		}
+		needComma = true
+
+		name, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		if err := json.Compact(&buf, extra[k]); err != nil {
+			return nil, fmt.Errorf("invalid extra field %q: %w", k, err)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unknownFields walks v and returns the path of every member recorded in an
+// Extra field, such as "records[0].score.new_metric", in a stable order.
+func unknownFields(v any) []string {
+	var out []string
+	collectUnknown(reflect.ValueOf(v), "", &out)
+	return out
+}
+
+func collectUnknown(v reflect.Value, path string, out *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectUnknown(v.Elem(), path, out)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			collectUnknown(v.Index(i), fmt.Sprintf("%s[%d]", path, i), out)
+		}
+	case reflect.Struct:
+		prefix := path
+		if prefix != "" {
+			prefix += "."
+		}
+		if extra := v.FieldByName("Extra"); extra.IsValid() && extra.Type() == reflect.TypeFor[map[string]json.RawMessage]() {
+			keys := make([]string, 0, extra.Len())
+			for _, k := range extra.MapKeys() {
+				keys = append(keys, prefix+k.String())
+			}
+			slices.Sort(keys)
+			*out = append(*out, keys...)
+		}
+		for f := range fieldsOf(v.Type()) {
+			collectUnknown(v.Field(f.index), prefix+f.name, out)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package whoop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExtra_RoundTrip(t *testing.T) {
+	input := `{"id":123,"ID":124,"start":"2026-02-24T05:00:00Z","score_state":"SCORED","new_field":{"a":[1,2]},` +
+		`"score":{"strain":12.4,"new_metric":7},"another":"x"}`
+
+	var c Cycle
+	if err := json.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Score == nil || c.Score.Strain != 12.4 {
+		t.Fatalf("expected known fields to decode, got %+v", c)
+	}
+	if len(c.Extra) != 2 || string(c.Extra["new_field"]) != `{"a":[1,2]}` || string(c.Extra["another"]) != `"x"` {
+		t.Errorf("unexpected top-level extra: %v", c.Extra)
+	}
+	if _, ok := c.Extra["ID"]; ok {
+		t.Error("expected case-insensitive match of a known field not to be kept as extra")
+	}
+	if string(c.Score.Extra["new_metric"]) != "7" {
+		t.Errorf("unexpected nested extra: %v", c.Score.Extra)
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(string(out), `"another":"x","new_field":{"a":[1,2]}}`) {
+		t.Errorf("expected extra members after the defined fields, got %s", out)
+	}
+	if !strings.Contains(string(out), `"max_heart_rate":0,"new_metric":7}`) {
+		t.Errorf("expected nested extra members to be written, got %s", out)
+	}
+
+	var again Cycle
+	if err := json.Unmarshal(out, &again); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(again.Extra) != 2 || string(again.Score.Extra["new_metric"]) != "7" {
+		t.Errorf("expected extra members to survive a round trip, got %v / %v", again.Extra, again.Score.Extra)
+	}
+}
+
+func TestExtra_NoUnknownFields(t *testing.T) {
+	var p BasicProfile
+	if err := json.Unmarshal([]byte(`{"user_id":1,"email":"a@example.com"}`), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Extra != nil {
+		t.Errorf("expected nil Extra, got %v", p.Extra)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"user_id":1,"email":"a@example.com","first_name":"","last_name":""}` {
+		t.Errorf("unexpected output %s", out)
+	}
+}
+
+func TestExtra_MarshalSkipsShadowingAndInvalidMembers(t *testing.T) {
+	m := BodyMeasurement{MaxHeartRate: 190, Extra: map[string]json.RawMessage{
+		"max_heart_rate": json.RawMessage(`1`), // shadowed by the defined field
+		"vo2_max":        json.RawMessage(` 52.5 `),
+	}}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"height_meter":0,"weight_kilogram":0,"max_heart_rate":190,"vo2_max":52.5}` {
+		t.Errorf("unexpected output %s", out)
+	}
+
+	m.Extra["broken"] = json.RawMessage(`{`)
+	if _, err := json.Marshal(m); err == nil {
+		t.Error("expected an error for invalid extra JSON")
+	}
+
+	out, err = marshalExtra(struct{}{}, map[string]json.RawMessage{"a": json.RawMessage(`1`)})
+	if err != nil || string(out) != `{"a":1}` {
+		t.Errorf("expected extra members in an empty object, got %s (%v)", out, err)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cycle/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":1,"score":{"strain":5,"new_metric":1}}`))
+	})
+	mux.HandleFunc("/activity/sleep", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"records":[{"id":"a"},{"id":"b","score":{"stage_summary":{"new_stage":1}}}],"next_token":""}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	ctx := context.Background()
+
+	lenient := NewClient(WithBaseURL(ts.URL), WithRateLimiting(false))
+	cycle, err := lenient.Cycle.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(cycle.Score.Extra["new_metric"]) != "1" {
+		t.Errorf("expected unknown field in Extra, got %v", cycle.Score.Extra)
+	}
+
+	strict := NewClient(WithBaseURL(ts.URL), WithRateLimiting(false), WithStrictDecoding(true))
+	_, err = strict.Cycle.GetByID(ctx, 1)
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("expected UnknownFieldsError, got %v", err)
+	}
+	if len(unknownErr.Fields) != 1 || unknownErr.Fields[0] != "score.new_metric" {
+		t.Errorf("unexpected fields %v", unknownErr.Fields)
+	}
+	if !strings.HasSuffix(unknownErr.URL, "/cycle/1") {
+		t.Errorf("unexpected URL %q", unknownErr.URL)
+	}
+
+	_, err = strict.Sleep.List(ctx, nil)
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("expected UnknownFieldsError from List, got %v", err)
+	}
+	if len(unknownErr.Fields) != 1 || unknownErr.Fields[0] != "records[1].score.stage_summary.new_stage" {
+		t.Errorf("unexpected fields %v", unknownErr.Fields)
+	}
+	if !strings.Contains(err.Error(), "records[1].score.stage_summary.new_stage") {
+		t.Errorf("expected the field path in the error message, got %q", err.Error())
+	}
+}
@@ -0,0 +1,321 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultManagerConcurrency is the number of users a Manager syncs at once
+// when no WithMaxConcurrentUsers option is provided.
+const defaultManagerConcurrency = 4
+
+// TokenStore supplies the users a Manager syncs and their access tokens.
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Users returns the IDs of the users to sync.
+	Users(ctx context.Context) ([]int, error)
+
+	// Token returns a valid access token for the user, refreshing it first
+	// if it has expired.
+	Token(ctx context.Context, userID int) (string, error)
+}
+
+// Job syncs one user with a client authenticated as that user.
+type Job func(ctx context.Context, userID int, client *Client) error
+
+// ManagerOption is a functional option for configuring a Manager.
+type ManagerOption func(*Manager)
+
+// WithMaxConcurrentUsers sets the maximum number of users a Manager runs jobs
+// for at once. By default, this is 4.
+func WithMaxConcurrentUsers(n int) ManagerOption {
+	return func(m *Manager) {
+		m.concurrency = n
+	}
+}
+
+// WithClientOptions sets options applied to every client a Manager builds,
+// such as WithBaseURL or WithMaxRetries. The manager sets each client's token
+// itself, and its HTTP client and rate limiter are shared, so WithHTTPClient
+// and WithRateLimiting affect every user.
+func WithClientOptions(opts ...Option) ManagerOption {
+	return func(m *Manager) {
+		m.clientOpts = append(m.clientOpts, opts...)
+	}
+}
+
+// withRateLimiter makes a client share rl with other clients.
+func withRateLimiter(rl *rateLimiter) Option {
+	return func(client *Client) {
+		client.rateLimiter = rl
+	}
+}
+
+// managedClient is a cached client and the token it was built with.
+type managedClient struct {
+	token  string
+	client *Client
+}
+
+// revocation records an auth failure and the token it happened with.
+type revocation struct {
+	token string
+	err   error
+}
+
+// Manager holds clients for many users. Clients are built lazily from the
+// TokenStore's tokens and share one HTTP client and one rate limiter, since
+// WHOOP's rate limits apply to the whole application rather than to each
+// user. A Manager is safe for concurrent use.
+type Manager struct {
+	tokens      TokenStore
+	concurrency int
+	clientOpts  []Option
+	httpClient  *http.Client
+	rateLimiter *rateLimiter
+
+	mu      sync.Mutex
+	clients map[int]*managedClient
+	revoked map[int]revocation
+	offset  int
+}
+
+// NewManager creates a Manager for the users in tokens.
+func NewManager(tokens TokenStore, opts ...ManagerOption) *Manager {
+	m := &Manager{
+		tokens:      tokens,
+		concurrency: defaultManagerConcurrency,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		rateLimiter: newRateLimiter(),
+		clients:     make(map[int]*managedClient),
+		revoked:     make(map[int]revocation),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Client returns a client authenticated as the user, building it on first
+// use and rebuilding it whenever the TokenStore returns a new token.
+func (m *Manager) Client(ctx context.Context, userID int) (*Client, error) {
+	token, err := m.tokens.Token(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.client(userID, token), nil
+}
+
+func (m *Manager) client(userID int, token string) *Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mc := m.clients[userID]; mc != nil && mc.token == token {
+		return mc.client
+	}
+
+	opts := make([]Option, 0, len(m.clientOpts)+3)
+	opts = append(opts, WithHTTPClient(m.httpClient), withRateLimiter(m.rateLimiter))
+	opts = append(opts, m.clientOpts...)
+	opts = append(opts, WithToken(token))
+	c := NewClient(opts...)
+	m.clients[userID] = &managedClient{token: token, client: c}
+	return c
+}
+
+// Revoked returns the users whose jobs failed with an *AuthError, mapped to
+// that error. Run skips them until their token changes, for example after
+// the user grants consent again, or until Reinstate is called.
+func (m *Manager) Revoked() map[int]error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[int]error, len(m.revoked))
+	for id, r := range m.revoked {
+		out[id] = r.err
+	}
+	return out
+}
+
+// Reinstate clears the user's revoked state, so the next Run includes them
+// even if their token has not changed.
+func (m *Manager) Reinstate(userID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.revoked, userID)
+}
+
+// RunResult holds the outcome of a Run. Every user appears in exactly one of
+// Completed, Errors or Skipped.
+type RunResult struct {
+	// Completed lists the users whose job returned nil, in ascending order.
+	Completed []int
+
+	// Errors maps each user whose token could not be fetched or whose job
+	// failed to the error. Users that were never started because ctx was
+	// canceled are reported with the context error.
+	Errors map[int]error
+
+	// Revoked lists the users in Errors whose job failed with an *AuthError
+	// during this run, in ascending order. Later runs skip them.
+	Revoked []int
+
+	// Skipped lists the users not run because they were revoked by an
+	// earlier run and their token has not changed, in ascending order.
+	Skipped []int
+}
+
+// Run runs job for every user in the TokenStore with at most the configured
+// number of users in flight. A failure for one user never stops the others;
+// an *AuthError, such as a 401 after the user revoked consent, additionally
+// marks the user revoked. Only a failure to list the users fails the run.
+//
+// Users are visited in ascending ID order starting at a rotating offset: each
+// run starts one user further along than the last, and a run cut short by ctx
+// resumes with the first user it did not start, so the same users are not
+// always last in line.
+//
+// When the run ends, cached clients and revocations of users the TokenStore
+// no longer lists are dropped.
+func (m *Manager) Run(ctx context.Context, job Job) (*RunResult, error) {
+	users, err := m.tokens.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users = slices.Clone(users)
+	slices.Sort(users)
+	users = slices.Compact(users)
+
+	concurrency := m.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	res := &RunResult{Errors: make(map[int]error)}
+	if len(users) == 0 {
+		m.mu.Lock()
+		m.prune(users)
+		m.mu.Unlock()
+		return res, nil
+	}
+
+	m.mu.Lock()
+	start := m.offset % len(users)
+	m.mu.Unlock()
+	next := start + 1
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	fail := func(userID int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		res.Errors[userID] = err
+	}
+
+	stopped := false
+	for i := range users {
+		pos := (start + i) % len(users)
+		userID := users[pos]
+
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		// Check cancellation after acquiring a slot too, since select picks
+		// randomly when a slot frees up just as ctx is canceled.
+		if err := ctx.Err(); err != nil {
+			if !stopped {
+				stopped, next = true, pos
+			}
+			fail(userID, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			m.runOne(ctx, userID, job, res, &mu)
+		}()
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	m.offset = next
+	m.prune(users)
+	m.mu.Unlock()
+
+	slices.Sort(res.Completed)
+	slices.Sort(res.Revoked)
+	slices.Sort(res.Skipped)
+	return res, nil
+}
+
+// prune drops the cached clients and revocations of users not in users, which
+// must be sorted, so users removed from the TokenStore are not kept for the
+// life of the Manager. m.mu must be held.
+func (m *Manager) prune(users []int) {
+	for id := range m.clients {
+		if _, ok := slices.BinarySearch(users, id); !ok {
+			delete(m.clients, id)
+		}
+	}
+	for id := range m.revoked {
+		if _, ok := slices.BinarySearch(users, id); !ok {
+			delete(m.revoked, id)
+		}
+	}
+}
+
+// runOne runs job for one user and records the outcome in res under mu.
+func (m *Manager) runOne(ctx context.Context, userID int, job Job, res *RunResult, mu *sync.Mutex) {
+	token, err := m.tokens.Token(ctx, userID)
+	if err != nil {
+		mu.Lock()
+		res.Errors[userID] = err
+		mu.Unlock()
+		return
+	}
+
+	m.mu.Lock()
+	r, revoked := m.revoked[userID]
+	if revoked && r.token != token {
+		delete(m.revoked, userID)
+		revoked = false
+	}
+	m.mu.Unlock()
+	if revoked {
+		mu.Lock()
+		res.Skipped = append(res.Skipped, userID)
+		mu.Unlock()
+		return
+	}
+
+	err = job(ctx, userID, m.client(userID, token))
+
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		m.mu.Lock()
+		m.revoked[userID] = revocation{token: token, err: err}
+		m.mu.Unlock()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	switch {
+	case err == nil:
+		res.Completed = append(res.Completed, userID)
+	case authErr != nil:
+		res.Errors[userID] = err
+		res.Revoked = append(res.Revoked, userID)
+	default:
+		res.Errors[userID] = err
+	}
+}
@@ -0,0 +1,251 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTokenStore hands out "token-<id>" unless a token is set explicitly.
+type fakeTokenStore struct {
+	mu     sync.Mutex
+	users  []int
+	tokens map[int]string
+	errs   map[int]error
+}
+
+func (s *fakeTokenStore) Users(context.Context) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.users, nil
+}
+
+func (s *fakeTokenStore) Token(_ context.Context, userID int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.errs[userID]; err != nil {
+		return "", err
+	}
+	if token, ok := s.tokens[userID]; ok {
+		return token, nil
+	}
+	return fmt.Sprintf("token-%d", userID), nil
+}
+
+func (s *fakeTokenStore) setUsers(users ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = users
+}
+
+func (s *fakeTokenStore) setToken(userID int, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[userID] = token
+}
+
+// newProfileServer answers /user/profile/basic with 401 for revokedToken.
+func newProfileServer(t *testing.T, revokedToken string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer "+revokedToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"consent revoked"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id":1}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func fetchProfile(ctx context.Context, _ int, c *Client) error {
+	_, err := c.User.GetBasicProfile(ctx)
+	return err
+}
+
+func TestManager_ClientsShareTransportAndLimiter(t *testing.T) {
+	store := &fakeTokenStore{users: []int{1, 2}, tokens: map[int]string{}}
+	m := NewManager(store, WithClientOptions(WithMaxRetries(7)))
+	ctx := context.Background()
+
+	a, err := m.Client(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := m.Client(ctx, 2)
+	if a == b || a.token != "token-1" || b.token != "token-2" {
+		t.Fatalf("expected separate clients per user, got tokens %q and %q", a.token, b.token)
+	}
+	if a.httpClient != b.httpClient || a.rateLimiter != b.rateLimiter {
+		t.Error("expected clients to share the HTTP client and rate limiter")
+	}
+	if a.maxRetries != 7 {
+		t.Errorf("expected client options to apply, got maxRetries %d", a.maxRetries)
+	}
+
+	if again, _ := m.Client(ctx, 1); again != a {
+		t.Error("expected the client to be cached")
+	}
+	store.setToken(1, "refreshed")
+	if again, _ := m.Client(ctx, 1); again == a || again.token != "refreshed" {
+		t.Error("expected a new client after the token changed")
+	}
+}
+
+func TestManager_RunRevokesOnAuthError(t *testing.T) {
+	ts := newProfileServer(t, "token-2")
+	tokenErr := errors.New("refresh failed")
+	store := &fakeTokenStore{users: []int{3, 1, 2, 4, 1}, tokens: map[int]string{}, errs: map[int]error{4: tokenErr}}
+	m := NewManager(store, WithClientOptions(WithBaseURL(ts.URL), WithRateLimiting(false)))
+	ctx := context.Background()
+
+	res, err := m.Run(ctx, fetchProfile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(res.Completed, []int{1, 3}) || !slices.Equal(res.Revoked, []int{2}) || len(res.Skipped) != 0 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	var authErr *AuthError
+	if !errors.As(res.Errors[2], &authErr) || authErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected an AuthError for user 2, got %v", res.Errors[2])
+	}
+	if !errors.Is(res.Errors[4], tokenErr) {
+		t.Errorf("expected the token error for user 4, got %v", res.Errors[4])
+	}
+	if _, ok := m.Revoked()[2]; !ok {
+		t.Errorf("expected user 2 to be revoked, got %v", m.Revoked())
+	}
+
+	// Revoked users are skipped until their token changes.
+	res, _ = m.Run(ctx, fetchProfile)
+	if !slices.Equal(res.Skipped, []int{2}) || len(res.Revoked) != 0 {
+		t.Errorf("expected user 2 to be skipped, got %+v", res)
+	}
+	store.setToken(2, "new-consent")
+	res, _ = m.Run(ctx, fetchProfile)
+	if !slices.Equal(res.Completed, []int{1, 2, 3}) || len(m.Revoked()) != 0 {
+		t.Errorf("expected user 2 to run with a new token, got %+v", res)
+	}
+
+	// Reinstate retries a revoked user with the same token.
+	store.setToken(2, "token-2")
+	_, _ = m.Run(ctx, fetchProfile)
+	m.Reinstate(2)
+	res, _ = m.Run(ctx, fetchProfile)
+	if !slices.Equal(res.Revoked, []int{2}) {
+		t.Errorf("expected user 2 to run again after Reinstate, got %+v", res)
+	}
+}
+
+func TestManager_RunConcurrencyCap(t *testing.T) {
+	store := &fakeTokenStore{users: []int{1, 2, 3, 4, 5, 6, 7, 8}, tokens: map[int]string{}}
+	m := NewManager(store, WithMaxConcurrentUsers(3))
+
+	var inFlight, peak atomic.Int32
+	res, err := m.Run(context.Background(), func(ctx context.Context, userID int, c *Client) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Completed) != 8 {
+		t.Errorf("expected every user to complete, got %+v", res)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("expected at most 3 users in flight, got %d", p)
+	}
+}
+
+func TestManager_RunRotatesStart(t *testing.T) {
+	store := &fakeTokenStore{users: []int{10, 20, 30}, tokens: map[int]string{}}
+	m := NewManager(store, WithMaxConcurrentUsers(1))
+
+	var firsts []int
+	for range 4 {
+		var first atomic.Int64
+		_, _ = m.Run(context.Background(), func(ctx context.Context, userID int, c *Client) error {
+			first.CompareAndSwap(0, int64(userID))
+			return nil
+		})
+		firsts = append(firsts, int(first.Load()))
+	}
+	if !slices.Equal(firsts, []int{10, 20, 30, 10}) {
+		t.Errorf("expected the first user to rotate, got %v", firsts)
+	}
+}
+
+func TestManager_RunCanceledResumes(t *testing.T) {
+	store := &fakeTokenStore{users: []int{1, 2, 3, 4}, tokens: map[int]string{}}
+	m := NewManager(store, WithMaxConcurrentUsers(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := m.Run(ctx, func(ctx context.Context, userID int, c *Client) error {
+		if userID == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(res.Completed, []int{1, 2}) || !errors.Is(res.Errors[3], context.Canceled) || !errors.Is(res.Errors[4], context.Canceled) {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	var order []int
+	_, _ = m.Run(context.Background(), func(ctx context.Context, userID int, c *Client) error {
+		order = append(order, userID)
+		return nil
+	})
+	if !slices.Equal(order, []int{3, 4, 1, 2}) {
+		t.Errorf("expected the next run to resume at the first unstarted user, got %v", order)
+	}
+}
+
+func TestManager_RunPrunesRemovedUsers(t *testing.T) {
+	ts := newProfileServer(t, "token-2")
+	store := &fakeTokenStore{users: []int{1, 2, 3}, tokens: map[int]string{}}
+	m := NewManager(store, WithClientOptions(WithBaseURL(ts.URL), WithRateLimiting(false)))
+	ctx := context.Background()
+
+	if _, err := m.Run(ctx, fetchProfile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.clients) != 3 || len(m.Revoked()) != 1 {
+		t.Fatalf("expected 3 clients and 1 revocation, got %d and %v", len(m.clients), m.Revoked())
+	}
+
+	store.setUsers(3)
+	if _, err := m.Run(ctx, fetchProfile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := m.clients[3]; !ok || len(m.clients) != 1 || len(m.Revoked()) != 0 {
+		t.Errorf("expected only user 3 to be kept, got %d clients and %v", len(m.clients), m.Revoked())
+	}
+
+	store.setUsers()
+	if _, err := m.Run(ctx, fetchProfile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.clients) != 0 {
+		t.Errorf("expected no clients, got %d", len(m.clients))
+	}
+}
@@ -0,0 +1,163 @@
+package whoop
+
+// The model types below decode JSON members they do not define into their
+// Extra field and write them back out when marshaled, so records survive a
+// round trip through this package without losing fields WHOOP adds later.
+// Each method converts to a local type with the same fields but no methods,
+// which lets encoding/json handle the defined fields as usual.
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (c *Cycle) UnmarshalJSON(data []byte) error {
+	type plain Cycle
+	return unmarshalExtra(data, (*plain)(c), &c.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (c Cycle) MarshalJSON() ([]byte, error) {
+	type plain Cycle
+	return marshalExtra(plain(c), c.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *Score) UnmarshalJSON(data []byte) error {
+	type plain Score
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s Score) MarshalJSON() ([]byte, error) {
+	type plain Score
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *Sleep) UnmarshalJSON(data []byte) error {
+	type plain Sleep
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s Sleep) MarshalJSON() ([]byte, error) {
+	type plain Sleep
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *SleepScore) UnmarshalJSON(data []byte) error {
+	type plain SleepScore
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s SleepScore) MarshalJSON() ([]byte, error) {
+	type plain SleepScore
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *StageSummary) UnmarshalJSON(data []byte) error {
+	type plain StageSummary
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s StageSummary) MarshalJSON() ([]byte, error) {
+	type plain StageSummary
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (n *SleepNeeded) UnmarshalJSON(data []byte) error {
+	type plain SleepNeeded
+	return unmarshalExtra(data, (*plain)(n), &n.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (n SleepNeeded) MarshalJSON() ([]byte, error) {
+	type plain SleepNeeded
+	return marshalExtra(plain(n), n.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (w *Workout) UnmarshalJSON(data []byte) error {
+	type plain Workout
+	return unmarshalExtra(data, (*plain)(w), &w.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (w Workout) MarshalJSON() ([]byte, error) {
+	type plain Workout
+	return marshalExtra(plain(w), w.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *WorkoutScore) UnmarshalJSON(data []byte) error {
+	type plain WorkoutScore
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s WorkoutScore) MarshalJSON() ([]byte, error) {
+	type plain WorkoutScore
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (z *ZoneDurations) UnmarshalJSON(data []byte) error {
+	type plain ZoneDurations
+	return unmarshalExtra(data, (*plain)(z), &z.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (z ZoneDurations) MarshalJSON() ([]byte, error) {
+	type plain ZoneDurations
+	return marshalExtra(plain(z), z.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (r *Recovery) UnmarshalJSON(data []byte) error {
+	type plain Recovery
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (r Recovery) MarshalJSON() ([]byte, error) {
+	type plain Recovery
+	return marshalExtra(plain(r), r.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (s *RecoveryScore) UnmarshalJSON(data []byte) error {
+	type plain RecoveryScore
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (s RecoveryScore) MarshalJSON() ([]byte, error) {
+	type plain RecoveryScore
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (p *BasicProfile) UnmarshalJSON(data []byte) error {
+	type plain BasicProfile
+	return unmarshalExtra(data, (*plain)(p), &p.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (p BasicProfile) MarshalJSON() ([]byte, error) {
+	type plain BasicProfile
+	return marshalExtra(plain(p), p.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown members in Extra.
+func (m *BodyMeasurement) UnmarshalJSON(data []byte) error {
+	type plain BodyMeasurement
+	return unmarshalExtra(data, (*plain)(m), &m.Extra)
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra after the defined fields.
+func (m BodyMeasurement) MarshalJSON() ([]byte, error) {
+	type plain BodyMeasurement
+	return marshalExtra(plain(m), m.Extra)
+}
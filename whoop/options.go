@@ -40,6 +40,25 @@ func WithBackoffMax(max time.Duration) Option {
 	}
 }
 
+// WithBatchConcurrency sets the maximum number of concurrent requests issued by
+// GetMany helpers such as WorkoutService.GetMany. By default, this is 4.
+// Every request still waits on the client's rate limiter.
+func WithBatchConcurrency(n int) Option {
+	return func(client *Client) {
+		client.batchConcurrency = n
+	}
+}
+
+// WithStrictDecoding makes every request fail with an *UnknownFieldsError
+// when a response contains fields the model types do not define. It is meant
+// for contract tests that should notice when the WHOOP API adds fields; by
+// default unknown fields are kept in each model's Extra field.
+func WithStrictDecoding(enabled bool) Option {
+	return func(client *Client) {
+		client.strictDecoding = enabled
+	}
+}
+
 // WithToken sets the OAuth2 access token for authentication.
 // This will automatically set the Authorization: Bearer <token> header on all requests.
 func WithToken(token string) Option {
@@ -63,3 +82,22 @@ func WithRateLimiting(enabled bool) Option {
 		client.rateLimiter.SetAutoLimiting(enabled)
 	}
 }
+
+// WithScopes sets the scopes granted to the token, typically from the token
+// response's scope parameter via ParseScopes. Methods then fail fast with a
+// *MissingScopeError, without a request, when their scope was not granted.
+// By default the granted scopes are unknown and nothing is checked. An empty
+// list also checks nothing, since token responses may omit scope when the
+// grant matches the request (RFC 6749, section 5.1).
+func WithScopes(scopes ...Scope) Option {
+	return func(client *Client) {
+		if len(scopes) == 0 {
+			client.scopes = nil
+			return
+		}
+		client.scopes = make(map[Scope]struct{}, len(scopes))
+		for _, s := range scopes {
+			client.scopes[s] = struct{}{}
+		}
+	}
+}
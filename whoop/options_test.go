@@ -40,6 +40,14 @@ func TestClient_Defaults(t *testing.T) {
 	if !client.rateLimiter.isAutoLimiting.Load() {
 		t.Error("expected rateLimiter auto limiting to be enabled by default")
 	}
+
+	if client.batchConcurrency != defaultBatchConcurrency {
+		t.Errorf("expected batchConcurrency %d, got %d", defaultBatchConcurrency, client.batchConcurrency)
+	}
+
+	if client.strictDecoding {
+		t.Error("expected strict decoding to be disabled by default")
+	}
 }
 
 func TestClient_Options(t *testing.T) {
@@ -55,6 +63,8 @@ func TestClient_Options(t *testing.T) {
 		WithToken(customToken),
 		WithBaseURL(customBaseURL),
 		WithRateLimiting(false),
+		WithBatchConcurrency(8),
+		WithStrictDecoding(true),
 	)
 
 	if client.httpClient != customHTTPClient {
@@ -84,4 +94,12 @@ func TestClient_Options(t *testing.T) {
 	if client.rateLimiter.isAutoLimiting.Load() {
 		t.Error("expected rateLimiter auto limiting to be disabled")
 	}
+
+	if client.batchConcurrency != 8 {
+		t.Errorf("expected batchConcurrency %d, got %d", 8, client.batchConcurrency)
+	}
+
+	if !client.strictDecoding {
+		t.Error("expected strict decoding to be enabled")
+	}
 }
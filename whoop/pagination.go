@@ -28,13 +28,14 @@ type ListOptions struct {
 	NextToken string `url:"nextToken,omitempty"`
 }
 
-// encode safely encodes ListOptions into query parameters.
-func (o *ListOptions) encode(u *url.URL) {
+// Query returns the options as URL query parameters. Unset options are
+// omitted, and a nil receiver returns empty values.
+func (o *ListOptions) Query() url.Values {
+	q := url.Values{}
 	if o == nil {
-		return
+		return q
 	}
 
-	q := u.Query()
 	if o.Limit > 0 {
 		q.Set("limit", strconv.Itoa(o.Limit))
 	}
@@ -47,6 +48,19 @@ func (o *ListOptions) encode(u *url.URL) {
 	if o.NextToken != "" {
 		q.Set("nextToken", o.NextToken)
 	}
+	return q
+}
+
+// encode safely encodes ListOptions into query parameters.
+func (o *ListOptions) encode(u *url.URL) {
+	if o == nil {
+		return
+	}
+
+	q := u.Query()
+	for k, v := range o.Query() {
+		q[k] = v
+	}
 
 	u.RawQuery = q.Encode()
 }
@@ -93,9 +107,67 @@ func getPaginated[T any](ctx context.Context, client *Client, path string, opts
 	}()
 
 	var p paginatedResponse[T]
-	if err = json.NewDecoder(resp.Body).Decode(&p); err != nil {
+	if err = client.decode(resp, &p); err != nil {
 		return nil, err
 	}
 
 	return &p, nil
 }
+
+// RawPage is a page of a collection whose records are kept as undecoded JSON,
+// for callers that copy responses as they are, such as archivers.
+type RawPage struct {
+	Records   []json.RawMessage
+	NextToken string
+
+	client *Client
+	path   string
+	opts   *ListOptions
+}
+
+// ListRaw fetches a page of the collection at path, one of CyclePath,
+// SleepPath, WorkoutPath or RecoveryPath. Like Get, it makes no scope check;
+// call CheckScopes first.
+func (c *Client) ListRaw(ctx context.Context, path string, opts *ListOptions) (*RawPage, error) {
+	page, err := getPaginated[json.RawMessage](ctx, c, path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawPage{
+		Records:   page.Records,
+		NextToken: page.NextToken,
+		client:    c,
+		path:      path,
+		opts:      opts,
+	}, nil
+}
+
+// NextPage fetches the subsequent page of the collection based on NextToken.
+// Returns an error if there is no next page.
+func (p *RawPage) NextPage(ctx context.Context) (*RawPage, error) {
+	if p.NextToken == "" {
+		return nil, ErrNoNextPage
+	}
+
+	return p.client.ListRaw(ctx, p.path, nextPageOpts(p.opts, p.NextToken))
+}
+
+// listAll fetches every page of a paginated resource, following next tokens
+// until the collection is exhausted.
+func listAll[T any](ctx context.Context, client *Client, path string, opts *ListOptions) ([]T, error) {
+	var all []T
+	pageOpts := opts
+	for {
+		page, err := getPaginated[T](ctx, client, path, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page.Records...)
+		if page.NextToken == "" {
+			return all, nil
+		}
+		pageOpts = nextPageOpts(opts, page.NextToken)
+	}
+}
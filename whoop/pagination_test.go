@@ -47,6 +47,19 @@ func TestListOptionsEncoding_Nil(t *testing.T) {
 	}
 }
 
+func TestListOptionsQuery(t *testing.T) {
+	var nilOpts *ListOptions
+	if q := nilOpts.Query(); len(q) != 0 {
+		t.Errorf("expected empty query for nil opts, got %v", q)
+	}
+
+	tm := time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+	q := (&ListOptions{Limit: 10, End: &tm}).Query()
+	if q.Encode() != "end=2026-02-24T00%3A00%3A00Z&limit=10" {
+		t.Errorf("unexpected query %q", q.Encode())
+	}
+}
+
 func TestServiceInitialization(t *testing.T) {
 	client := NewClient()
 
@@ -127,6 +140,37 @@ func TestCycleService_List_Pagination(t *testing.T) {
 	}
 }
 
+func TestClient_ListRaw(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != SleepPath || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("nextToken") == "" {
+			_, _ = w.Write([]byte(`{"records": [{"id": "a", "new_field": 1}], "next_token": "page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records": [{"id": "b"}], "next_token": ""}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL), WithStrictDecoding(true))
+	page, err := client.ListRaw(context.Background(), SleepPath, &ListOptions{Limit: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Records) != 1 || string(page.Records[0]) != `{"id": "a", "new_field": 1}` {
+		t.Errorf("expected the raw record, got %s", page.Records)
+	}
+
+	page, err = page.NextPage(context.Background())
+	if err != nil || len(page.Records) != 1 || string(page.Records[0]) != `{"id": "b"}` {
+		t.Fatalf("unexpected second page %v, %v", page, err)
+	}
+	if _, err := page.NextPage(context.Background()); !errors.Is(err, ErrNoNextPage) {
+		t.Errorf("expected ErrNoNextPage, got %v", err)
+	}
+}
+
 func TestGetPaginated_DecodeError(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
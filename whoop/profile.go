@@ -2,6 +2,7 @@ package whoop
 
 import (
 	"context"
+	"encoding/json"
 )
 
 // BasicProfile represents the user's basic profile information.
@@ -10,6 +11,9 @@ type BasicProfile struct {
 	Email     string `json:"email"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
+
+	// Extra holds the JSON members not mapped to a field of BasicProfile.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BodyMeasurement represents the user's physical body measurements.
@@ -17,8 +21,17 @@ type BodyMeasurement struct {
 	HeightMeter    float64 `json:"height_meter"`
 	WeightKilogram float64 `json:"weight_kilogram"`
 	MaxHeartRate   int     `json:"max_heart_rate"`
+
+	// Extra holds the JSON members not mapped to a field of BodyMeasurement.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// API paths of the user's profile resources.
+const (
+	BasicProfilePath    = "/user/profile/basic"
+	BodyMeasurementPath = "/user/measurement/body"
+)
+
 // UserService handles communication with the user related methods.
 type UserService struct {
 	client *Client
@@ -26,8 +39,11 @@ type UserService struct {
 
 // GetBasicProfile fetches the athlete's basic profile.
 func (s *UserService) GetBasicProfile(ctx context.Context) (profile *BasicProfile, err error) {
+	if err := s.client.requireScope(ScopeReadProfile); err != nil {
+		return nil, err
+	}
 	var p BasicProfile
-	if err = s.client.Get(ctx, "/user/profile/basic", &p); err != nil {
+	if err = s.client.Get(ctx, BasicProfilePath, &p); err != nil {
 		return nil, err
 	}
 
@@ -36,8 +52,11 @@ func (s *UserService) GetBasicProfile(ctx context.Context) (profile *BasicProfil
 
 // GetBodyMeasurement fetches the athlete's body measurements.
 func (s *UserService) GetBodyMeasurement(ctx context.Context) (measurement *BodyMeasurement, err error) {
+	if err := s.client.requireScope(ScopeReadBodyMeasurement); err != nil {
+		return nil, err
+	}
 	var m BodyMeasurement
-	if err = s.client.Get(ctx, "/user/measurement/body", &m); err != nil {
+	if err = s.client.Get(ctx, BodyMeasurementPath, &m); err != nil {
 		return nil, err
 	}
 
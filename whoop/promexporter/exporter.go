@@ -0,0 +1,194 @@
+// Package promexporter serves the latest WHOOP metrics of one or more users in
+// the Prometheus text exposition format.
+//
+// An Exporter periodically fetches each user's latest scored recovery, sleep
+// and cycle and keeps them in memory; scrapes of its /metrics handler never
+// call the WHOOP API. Every sample carries a user label:
+//
+//	exp := promexporter.New(5*time.Minute,
+//	    promexporter.Target{User: "alice", Client: whoop.NewClient(whoop.WithToken(aliceToken))},
+//	)
+//	go exp.Run(ctx)
+//	http.Handle("/metrics", exp)
+package promexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// DefaultInterval is the refresh interval used when New is given a
+// non-positive interval.
+const DefaultInterval = 5 * time.Minute
+
+// latestLimit is the page size used to find the most recent scored record.
+const latestLimit = 10
+
+// Error kinds used as the kind label of whoop_api_errors_total.
+const (
+	ErrorKindAuth      = "auth"
+	ErrorKindRateLimit = "rate_limit"
+	ErrorKindAPI       = "api"
+	ErrorKindOther     = "other"
+)
+
+// Target is a user whose metrics are exported.
+type Target struct {
+	// User is the value of the user label. It must be unique among targets.
+	User string
+
+	// Client is authenticated as the user.
+	Client *whoop.Client
+}
+
+// snapshot holds the latest records fetched for one user.
+type snapshot struct {
+	recovery *whoop.Recovery
+	sleep    *whoop.Sleep
+	cycle    *whoop.Cycle
+
+	lastSuccess time.Time
+	up          bool
+	errors      map[string]uint64 // by error kind
+}
+
+// Exporter fetches the latest metrics for its targets and serves them over
+// HTTP. It is safe for concurrent use.
+type Exporter struct {
+	targets  []Target
+	interval time.Duration
+
+	mu        sync.RWMutex
+	snapshots map[string]*snapshot
+}
+
+// New returns an Exporter that refreshes targets every interval.
+func New(interval time.Duration, targets ...Target) *Exporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	e := &Exporter{
+		targets:   targets,
+		interval:  interval,
+		snapshots: make(map[string]*snapshot, len(targets)),
+	}
+	for _, t := range targets {
+		e.snapshots[t.User] = &snapshot{errors: make(map[string]uint64)}
+	}
+	return e
+}
+
+// Run refreshes all targets immediately and then every interval until ctx is
+// done, and returns ctx.Err(). Failures are recorded in the exported error
+// counters rather than stopping the loop.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the latest records for every target concurrently and
+// returns once all of them have finished.
+func (e *Exporter) Refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, t := range e.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.refreshTarget(ctx, t)
+		}()
+	}
+	wg.Wait()
+}
+
+func (e *Exporter) refreshTarget(ctx context.Context, t Target) {
+	opts := &whoop.ListOptions{Limit: latestLimit}
+	var errs []error
+
+	var recovery *whoop.Recovery
+	if page, err := t.Client.Recovery.List(ctx, opts); err != nil {
+		errs = append(errs, err)
+	} else {
+		recovery = latest(page.Records, func(r *whoop.Recovery) bool { return r.IsScored() })
+	}
+
+	var sleep *whoop.Sleep
+	if page, err := t.Client.Sleep.List(ctx, opts); err != nil {
+		errs = append(errs, err)
+	} else {
+		sleep = latest(page.Records, func(s *whoop.Sleep) bool { return !s.Nap && s.IsScored() })
+	}
+
+	var cycle *whoop.Cycle
+	if page, err := t.Client.Cycle.List(ctx, opts); err != nil {
+		errs = append(errs, err)
+	} else {
+		cycle = latest(page.Records, func(c *whoop.Cycle) bool { return c.IsScored() })
+	}
+
+	// Requests cut short by shutdown are not API failures.
+	if ctx.Err() != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s := e.snapshots[t.User]
+	for _, err := range errs {
+		s.errors[errorKind(err)]++
+	}
+	s.up = len(errs) == 0
+	if recovery != nil {
+		s.recovery = recovery
+	}
+	if sleep != nil {
+		s.sleep = sleep
+	}
+	if cycle != nil {
+		s.cycle = cycle
+	}
+	if s.up {
+		s.lastSuccess = time.Now()
+	}
+}
+
+// latest returns the first record matching keep. The API lists records
+// newest first.
+func latest[T any](records []T, keep func(*T) bool) *T {
+	for i := range records {
+		if keep(&records[i]) {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// errorKind classifies err for the kind label of whoop_api_errors_total.
+func errorKind(err error) string {
+	var authErr *whoop.AuthError
+	var rateErr *whoop.RateLimitError
+	var apiErr *whoop.APIError
+	switch {
+	case errors.As(err, &authErr):
+		return ErrorKindAuth
+	case errors.As(err, &rateErr):
+		return ErrorKindRateLimit
+	case errors.As(err, &apiErr):
+		return ErrorKindAPI
+	default:
+		return ErrorKindOther
+	}
+}
@@ -0,0 +1,176 @@
+package promexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// newAPIServer serves one page each of recoveries, sleeps and cycles. The
+// newest record of every collection is unscored, so the exporter has to skip
+// it.
+func newAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/recovery", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "10" {
+			t.Errorf("expected limit=10, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("X-RateLimit-Remaining", "97")
+		_, _ = w.Write([]byte(`{"records":[
+			{"cycle_id":3,"score_state":"PENDING_SCORE"},
+			{"cycle_id":2,"score_state":"SCORED","score":{"recovery_score":66,"resting_heart_rate":52,"hrv_rmssd_milli":48.5,"spo2_percentage":96.1,"skin_temp_celsius":33.7}}
+		]}`))
+	})
+	mux.HandleFunc("/activity/sleep", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "98")
+		_, _ = w.Write([]byte(`{"records":[
+			{"id":"nap","nap":true,"score_state":"SCORED","score":{"sleep_performance_percentage":10}},
+			{"id":"main","score_state":"SCORED","score":{"respiratory_rate":15.2,"sleep_performance_percentage":91,
+				"stage_summary":{"total_light_sleep_time_milli":14400000,"total_slow_wave_sleep_time_milli":5400000,"total_rem_sleep_time_milli":6300000,"total_awake_time_milli":1800000}}}
+		]}`))
+	})
+	mux.HandleFunc("/cycle", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "96")
+		_, _ = w.Write([]byte(`{"records":[
+			{"id":3,"score_state":"UNSCORABLE"},
+			{"id":2,"score_state":"SCORED","score":{"strain":12.4,"kilojoule":9000}}
+		]}`))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestClient(url string) *whoop.Client {
+	return whoop.NewClient(whoop.WithBaseURL(url), whoop.WithRateLimiting(false))
+}
+
+func TestExporter_Refresh(t *testing.T) {
+	ts := newAPIServer(t)
+	e := New(time.Minute, Target{User: "alice", Client: newTestClient(ts.URL)})
+
+	before := time.Now()
+	e.Refresh(context.Background())
+
+	s := e.snapshots["alice"]
+	if !s.up {
+		t.Fatalf("expected up after a successful refresh, errors %v", s.errors)
+	}
+	if s.recovery == nil || s.recovery.CycleID != 2 {
+		t.Errorf("expected the latest scored recovery, got %+v", s.recovery)
+	}
+	if s.sleep == nil || s.sleep.ID != "main" {
+		t.Errorf("expected the latest scored main sleep, got %+v", s.sleep)
+	}
+	if s.cycle == nil || s.cycle.ID != 2 {
+		t.Errorf("expected the latest scored cycle, got %+v", s.cycle)
+	}
+	if s.lastSuccess.Before(before) {
+		t.Errorf("expected last success to be set, got %v", s.lastSuccess)
+	}
+}
+
+func TestExporter_RefreshErrors(t *testing.T) {
+	ts := newAPIServer(t)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/recovery":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/activity/sleep":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{"records":[]}`))
+		}
+	}))
+	defer failing.Close()
+
+	e := New(time.Minute, Target{User: "alice", Client: newTestClient(ts.URL)})
+	ctx := context.Background()
+	e.Refresh(ctx)
+
+	// Point the same user at a failing API; earlier records are kept.
+	e.targets[0].Client = newTestClient(failing.URL)
+	e.Refresh(ctx)
+	e.Refresh(ctx)
+
+	s := e.snapshots["alice"]
+	if s.up {
+		t.Error("expected down after a failed refresh")
+	}
+	if s.errors[ErrorKindAuth] != 2 || s.errors[ErrorKindAPI] != 2 || s.errors[ErrorKindOther] != 0 {
+		t.Errorf("unexpected error counts %v", s.errors)
+	}
+	if s.recovery == nil || s.sleep == nil || s.cycle == nil {
+		t.Error("expected records from the earlier refresh to be kept")
+	}
+	if s.lastSuccess.IsZero() {
+		t.Error("expected the earlier success time to be kept")
+	}
+}
+
+func TestExporter_RefreshCanceled(t *testing.T) {
+	ts := newAPIServer(t)
+	e := New(time.Minute, Target{User: "alice", Client: newTestClient(ts.URL)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.Refresh(ctx)
+
+	s := e.snapshots["alice"]
+	if len(s.errors) != 0 || s.up || !s.lastSuccess.IsZero() {
+		t.Errorf("expected a canceled refresh to record nothing, got %+v", s)
+	}
+}
+
+func TestExporter_Run(t *testing.T) {
+	ts := newAPIServer(t)
+	e := New(0, Target{User: "alice", Client: newTestClient(ts.URL)})
+	if e.interval != DefaultInterval {
+		t.Errorf("expected default interval, got %v", e.interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- e.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e.mu.RLock()
+		up := e.snapshots["alice"].up
+		e.mu.RUnlock()
+		if up {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected Run to refresh immediately")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&whoop.AuthError{StatusCode: 401}, ErrorKindAuth},
+		{&whoop.RateLimitError{}, ErrorKindRateLimit},
+		{&whoop.APIError{StatusCode: 500}, ErrorKindAPI},
+		{context.DeadlineExceeded, ErrorKindOther},
+	}
+	for _, tt := range tests {
+		if got := errorKind(tt.err); got != tt.want {
+			t.Errorf("errorKind(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
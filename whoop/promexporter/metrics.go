@@ -0,0 +1,184 @@
+package promexporter
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// contentType is the media type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// label is a metric label name and value.
+type label struct {
+	name  string
+	value string
+}
+
+// sample is one labelled value of a metric family.
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a metric name with its help text, type and samples.
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+func (f *family) add(value float64, labels ...label) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// ServeHTTP writes the current metrics in the Prometheus text exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_ = e.WriteMetrics(w)
+}
+
+// WriteMetrics writes the current metrics in the Prometheus text exposition
+// format to w. Gauges for records that have not been fetched yet are omitted.
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	var b strings.Builder
+	for _, f := range e.families() {
+		if len(f.samples) == 0 {
+			continue
+		}
+		b.WriteString("# HELP " + f.name + " " + f.help + "\n")
+		b.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			writeLabels(&b, s.labels)
+			b.WriteByte(' ')
+			b.WriteString(formatValue(s.value))
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// families builds the metric families from the current snapshots, with
+// samples ordered by user.
+func (e *Exporter) families() []*family {
+	recoveryScore := &family{name: "whoop_recovery_score", help: "Latest recovery score percentage.", typ: "gauge"}
+	hrv := &family{name: "whoop_hrv_rmssd_ms", help: "Heart rate variability (RMSSD) of the latest recovery in milliseconds.", typ: "gauge"}
+	restingHR := &family{name: "whoop_resting_hr_bpm", help: "Resting heart rate of the latest recovery in beats per minute.", typ: "gauge"}
+	spo2 := &family{name: "whoop_spo2_percent", help: "Blood oxygen saturation of the latest recovery.", typ: "gauge"}
+	skinTemp := &family{name: "whoop_skin_temp_celsius", help: "Skin temperature of the latest recovery in degrees Celsius.", typ: "gauge"}
+	strain := &family{name: "whoop_strain", help: "Strain of the latest scored cycle.", typ: "gauge"}
+	kilojoules := &family{name: "whoop_cycle_kilojoules", help: "Energy expended during the latest scored cycle in kilojoules.", typ: "gauge"}
+	stages := &family{name: "whoop_sleep_stage_seconds", help: "Time spent in each stage during the latest main sleep.", typ: "gauge"}
+	performance := &family{name: "whoop_sleep_performance_percent", help: "Sleep performance of the latest main sleep.", typ: "gauge"}
+	respiratory := &family{name: "whoop_respiratory_rate", help: "Respiratory rate during the latest main sleep in breaths per minute.", typ: "gauge"}
+	up := &family{name: "whoop_up", help: "Whether the last refresh for the user succeeded.", typ: "gauge"}
+	lastSuccess := &family{name: "whoop_last_success_timestamp_seconds", help: "Unix time of the last successful refresh.", typ: "gauge"}
+	apiErrors := &family{name: "whoop_api_errors_total", help: "WHOOP API requests that failed, by error kind.", typ: "counter"}
+	rateLimit := &family{name: "whoop_rate_limit_remaining", help: "Requests remaining in the current WHOOP rate limit window.", typ: "gauge"}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	users := make([]string, 0, len(e.snapshots))
+	for user := range e.snapshots {
+		users = append(users, user)
+	}
+	slices.Sort(users)
+
+	clients := make(map[string]*whoop.Client, len(e.targets))
+	for _, t := range e.targets {
+		clients[t.User] = t.Client
+	}
+
+	for _, user := range users {
+		s := e.snapshots[user]
+		u := label{"user", user}
+
+		if r := s.recovery; r != nil {
+			recoveryScore.add(r.Score.RecoveryScore, u)
+			hrv.add(r.Score.HrvRmssdMilli, u)
+			restingHR.add(r.Score.RestingHeartRate, u)
+			spo2.add(r.Score.Spo2Percentage, u)
+			skinTemp.add(r.Score.SkinTempCelsius, u)
+		}
+		if c := s.cycle; c != nil {
+			strain.add(c.Score.Strain, u)
+			kilojoules.add(c.Score.Kilojoule, u)
+		}
+		if sl := s.sleep; sl != nil {
+			if st := sl.Score.StageSummary; st != nil {
+				stages.add(st.TotalLightSleepTime().Seconds(), u, label{"stage", "light"})
+				stages.add(st.TotalSlowWaveSleepTime().Seconds(), u, label{"stage", "slow_wave"})
+				stages.add(st.TotalRemSleepTime().Seconds(), u, label{"stage", "rem"})
+				stages.add(st.TotalAwakeTime().Seconds(), u, label{"stage", "awake"})
+			}
+			performance.add(sl.Score.SleepPerformancePercentage, u)
+			respiratory.add(sl.Score.RespiratoryRate, u)
+		}
+
+		up.add(boolValue(s.up), u)
+		if !s.lastSuccess.IsZero() {
+			lastSuccess.add(float64(s.lastSuccess.Unix()), u)
+		}
+		for _, kind := range []string{ErrorKindAuth, ErrorKindRateLimit, ErrorKindAPI, ErrorKindOther} {
+			apiErrors.add(float64(s.errors[kind]), u, label{"kind", kind})
+		}
+		if remaining, ok := clients[user].RateLimitRemaining(); ok {
+			rateLimit.add(float64(remaining), u)
+		}
+	}
+
+	return []*family{
+		recoveryScore, hrv, restingHR, spo2, skinTemp,
+		strain, kilojoules,
+		stages, performance, respiratory,
+		up, lastSuccess, apiErrors, rateLimit,
+	}
+}
+
+func writeLabels(w *strings.Builder, labels []label) {
+	if len(labels) == 0 {
+		return
+	}
+	w.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(l.name)
+		w.WriteString(`="`)
+		w.WriteString(labelEscaper.Replace(l.value))
+		w.WriteByte('"')
+	}
+	w.WriteByte('}')
+}
+
+// labelEscaper escapes label values as required by the exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package promexporter
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter_ServeHTTP(t *testing.T) {
+	ts := newAPIServer(t)
+	e := New(time.Minute,
+		Target{User: "bob", Client: newTestClient(ts.URL)},
+		Target{User: "alice", Client: newTestClient(ts.URL)},
+	)
+	e.Refresh(context.Background())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != contentType {
+		t.Errorf("unexpected content type %q", got)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# HELP whoop_recovery_score Latest recovery score percentage.",
+		"# TYPE whoop_recovery_score gauge",
+		`whoop_recovery_score{user="alice"} 66`,
+		`whoop_hrv_rmssd_ms{user="alice"} 48.5`,
+		`whoop_resting_hr_bpm{user="alice"} 52`,
+		`whoop_spo2_percent{user="alice"} 96.1`,
+		`whoop_skin_temp_celsius{user="alice"} 33.7`,
+		`whoop_strain{user="alice"} 12.4`,
+		`whoop_cycle_kilojoules{user="alice"} 9000`,
+		`whoop_sleep_stage_seconds{user="alice",stage="light"} 14400`,
+		`whoop_sleep_stage_seconds{user="alice",stage="slow_wave"} 5400`,
+		`whoop_sleep_stage_seconds{user="alice",stage="rem"} 6300`,
+		`whoop_sleep_stage_seconds{user="alice",stage="awake"} 1800`,
+		`whoop_sleep_performance_percent{user="alice"} 91`,
+		`whoop_respiratory_rate{user="alice"} 15.2`,
+		`whoop_up{user="alice"} 1`,
+		"# TYPE whoop_api_errors_total counter",
+		`whoop_api_errors_total{user="alice",kind="auth"} 0`,
+		`whoop_rate_limit_remaining{user="alice"} 96`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected line %q in:\n%s", line, body)
+		}
+	}
+	if !strings.Contains(body, `whoop_last_success_timestamp_seconds{user="alice"} `) {
+		t.Error("expected a last success timestamp")
+	}
+	if strings.Index(body, `whoop_up{user="alice"}`) > strings.Index(body, `whoop_up{user="bob"}`) {
+		t.Error("expected samples ordered by user")
+	}
+}
+
+func TestExporter_WriteMetricsBeforeRefresh(t *testing.T) {
+	e := New(time.Minute, Target{User: "alice", Client: newTestClient("http://127.0.0.1:0")})
+
+	var b strings.Builder
+	if err := e.WriteMetrics(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := b.String()
+	if strings.Contains(got, "whoop_recovery_score") || strings.Contains(got, "whoop_last_success_timestamp_seconds") ||
+		strings.Contains(got, "whoop_rate_limit_remaining") {
+		t.Errorf("expected no gauges for data not fetched yet, got:\n%s", got)
+	}
+	if !strings.Contains(got, `whoop_up{user="alice"} 0`+"\n") {
+		t.Errorf("expected whoop_up 0, got:\n%s", got)
+	}
+}
+
+func TestWriteLabels_Escaping(t *testing.T) {
+	var b strings.Builder
+	writeLabels(&b, []label{{"user", "a\\b\"c\nd"}, {"kind", "x"}})
+	if got, want := b.String(), `{user="a\\b\"c\nd",kind="x"}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{66, "66"},
+		{0.25, "0.25"},
+		{1e21, "1e+21"},
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+	}
+	for _, tt := range tests {
+		if got := formatValue(tt.v); got != tt.want {
+			t.Errorf("formatValue(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package whoop
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -13,8 +14,16 @@ type Recovery struct {
 	UserID     int            `json:"user_id"`
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
-	ScoreState string         `json:"score_state"`
+	ScoreState ScoreState     `json:"score_state"`
 	Score      *RecoveryScore `json:"score,omitempty"`
+
+	// Extra holds the JSON members not mapped to a field of Recovery.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IsScored reports whether the recovery has been scored and its Score is populated.
+func (r *Recovery) IsScored() bool {
+	return r.ScoreState.IsScored() && r.Score != nil
 }
 
 // RecoveryScore contains the metrics formulating the recovery calculation.
@@ -25,8 +34,15 @@ type RecoveryScore struct {
 	HrvRmssdMilli    float64 `json:"hrv_rmssd_milli"`
 	Spo2Percentage   float64 `json:"spo2_percentage"`
 	SkinTempCelsius  float64 `json:"skin_temp_celsius"`
+
+	// Extra holds the JSON members not mapped to a field of RecoveryScore.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// RecoveryPath is the API path of the recovery collection. A single
+// recovery is fetched under its cycle instead.
+const RecoveryPath = "/recovery"
+
 // RecoveryService handles communication with the recovery related methods.
 type RecoveryService struct {
 	client *Client
@@ -34,17 +50,41 @@ type RecoveryService struct {
 
 // GetByID fetches a single recovery score by cycle ID.
 func (s *RecoveryService) GetByID(ctx context.Context, cycleID int) (*Recovery, error) {
+	if err := s.client.requireScope(ScopeReadRecovery); err != nil {
+		return nil, err
+	}
 	var item Recovery
-	if err := s.client.Get(ctx, fmt.Sprintf("/cycle/%d/recovery", cycleID), &item); err != nil {
+	if err := s.client.Get(ctx, fmt.Sprintf(CyclePath+"/%d/recovery", cycleID), &item); err != nil {
 		return nil, err
 	}
 
 	return &item, nil
 }
 
+// GetManyByCycle fetches the recoveries for multiple cycle IDs concurrently,
+// bounded by the client's batch concurrency. Per-cycle failures, including 404s
+// for cycles without a recovery, are reported in the result's Errors map
+// without failing the whole batch.
+func (s *RecoveryService) GetManyByCycle(ctx context.Context, cycleIDs []int) *BatchResult[int, Recovery] {
+	return getMany(ctx, s.client.batchConcurrency, cycleIDs, s.GetByID)
+}
+
+// WaitForScore polls GetByID every pollInterval until the recovery for the given
+// cycle is scored or unscorable, which is typically needed right after a
+// recovery webhook event arrives. A non-positive pollInterval defaults to 5 seconds.
+func (s *RecoveryService) WaitForScore(ctx context.Context, cycleID int, pollInterval time.Duration) (*Recovery, error) {
+	return waitForScore(ctx, pollInterval,
+		func(ctx context.Context) (*Recovery, error) { return s.GetByID(ctx, cycleID) },
+		func(item *Recovery) ScoreState { return item.ScoreState },
+	)
+}
+
 // List fetches a paginated collection of recovery records.
 func (s *RecoveryService) List(ctx context.Context, opts *ListOptions) (*RecoveryPage, error) {
-	page, err := getPaginated[Recovery](ctx, s.client, "/recovery", opts)
+	if err := s.client.requireScope(ScopeReadRecovery); err != nil {
+		return nil, err
+	}
+	page, err := getPaginated[Recovery](ctx, s.client, RecoveryPath, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -1,5 +1,10 @@
 package whoop
 
+import (
+	"slices"
+	"strings"
+)
+
 // Scope represents an OAuth2 scope required to access specific WHOOP API endpoints.
 type Scope string
 
@@ -25,3 +30,86 @@ const (
 	// ScopeReadBodyMeasurement allows reading the user's body measurements.
 	ScopeReadBodyMeasurement Scope = "read:body_measurement"
 )
+
+// Service identifies a group of API methods, for ScopesFor.
+type Service string
+
+// Services of the WHOOP API.
+const (
+	ServiceCycle           Service = "cycle"
+	ServiceSleep           Service = "sleep"
+	ServiceWorkout         Service = "workout"
+	ServiceRecovery        Service = "recovery"
+	ServiceProfile         Service = "profile"
+	ServiceBodyMeasurement Service = "body_measurement"
+)
+
+// serviceScopes maps each service to the scopes its methods require.
+var serviceScopes = map[Service][]Scope{
+	ServiceCycle:           {ScopeReadCycles},
+	ServiceSleep:           {ScopeReadSleep},
+	ServiceWorkout:         {ScopeReadWorkout},
+	ServiceRecovery:        {ScopeReadRecovery},
+	ServiceProfile:         {ScopeReadProfile},
+	ServiceBodyMeasurement: {ScopeReadBodyMeasurement},
+}
+
+// ScopesFor returns the minimal scopes to request for an authorization URL
+// so that the given services can be used, without duplicates and in a stable
+// order. Add ScopeOffline to receive a refresh token.
+func ScopesFor(services ...Service) []Scope {
+	var scopes []Scope
+	for _, s := range services {
+		for _, scope := range serviceScopes[s] {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	slices.Sort(scopes)
+	return scopes
+}
+
+// JoinScopes formats scopes as the space-separated list used by the OAuth2
+// scope parameter.
+func JoinScopes(scopes []Scope) string {
+	parts := make([]string, len(scopes))
+	for i, s := range scopes {
+		parts[i] = string(s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseScopes parses the space-separated scope parameter of an OAuth2 token
+// response, for use with WithScopes.
+func ParseScopes(s string) []Scope {
+	fields := strings.Fields(s)
+	scopes := make([]Scope, len(fields))
+	for i, f := range fields {
+		scopes[i] = Scope(f)
+	}
+	return scopes
+}
+
+// CheckScopes returns a *MissingScopeError if the client's token was not
+// granted the scopes of every given service. Clients without WithScopes
+// check nothing. Service methods check their own scopes; CheckScopes is for
+// code that calls the API through Get or Do, or that should fail before
+// starting a multi-step job.
+func (c *Client) CheckScopes(services ...Service) error {
+	return c.requireScope(ScopesFor(services...)...)
+}
+
+// requireScope returns a *MissingScopeError for the first of scopes the
+// client's token was not granted. Clients without WithScopes check nothing.
+func (c *Client) requireScope(scopes ...Scope) error {
+	if c.scopes == nil {
+		return nil
+	}
+	for _, s := range scopes {
+		if _, ok := c.scopes[s]; !ok {
+			return &MissingScopeError{Scope: s}
+		}
+	}
+	return nil
+}
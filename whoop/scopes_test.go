@@ -0,0 +1,100 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseScopes(t *testing.T) {
+	got := ParseScopes(" offline  read:sleep\tread:cycles ")
+	if want := []Scope{ScopeOffline, ScopeReadSleep, ScopeReadCycles}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := ParseScopes(""); len(got) != 0 {
+		t.Errorf("expected no scopes, got %v", got)
+	}
+}
+
+func TestScopesFor(t *testing.T) {
+	got := ScopesFor(ServiceSleep, ServiceCycle, ServiceSleep, ServiceRecovery)
+	if want := []Scope{ScopeReadCycles, ScopeReadRecovery, ScopeReadSleep}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := JoinScopes(append([]Scope{ScopeOffline}, got...)); got != "offline read:cycles read:recovery read:sleep" {
+		t.Errorf("unexpected joined scopes %q", got)
+	}
+	for s := range serviceScopes {
+		if len(ScopesFor(s)) == 0 {
+			t.Errorf("service %q requires no scope", s)
+		}
+	}
+}
+
+func TestClient_RequireScope(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"records":[]}`))
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	client := newMockClient(ts, WithScopes(ParseScopes("offline read:cycles")...))
+	calls := map[string]func() error{
+		"Sleep.GetByID": func() error { _, err := client.Sleep.GetByID(ctx, "x"); return err },
+		"Sleep.List":    func() error { _, err := client.Sleep.List(ctx, nil); return err },
+		"Workout.ListFiltered": func() error {
+			_, err := client.Workout.ListFiltered(ctx, nil, WorkoutFilter{})
+			return err
+		},
+		"Recovery.GetByID":        func() error { _, err := client.Recovery.GetByID(ctx, 1); return err },
+		"User.GetBasicProfile":    func() error { _, err := client.User.GetBasicProfile(ctx); return err },
+		"User.GetBodyMeasurement": func() error { _, err := client.User.GetBodyMeasurement(ctx); return err },
+		"Days": func() error {
+			_, err := client.Days(ctx, time.Now().Add(-time.Hour), time.Now())
+			return err
+		},
+	}
+	for name, call := range calls {
+		var scopeErr *MissingScopeError
+		if err := call(); !errors.As(err, &scopeErr) {
+			t.Errorf("%s: expected a MissingScopeError, got %v", name, err)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+
+	_, err := client.Sleep.List(ctx, nil)
+	var scopeErr *MissingScopeError
+	if !errors.As(err, &scopeErr) || scopeErr.Scope != ScopeReadSleep || err.Error() != `whoop token is missing the "read:sleep" scope` {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if err := client.CheckScopes(ServiceCycle, ServiceWorkout); !errors.As(err, &scopeErr) || scopeErr.Scope != ScopeReadWorkout {
+		t.Errorf("expected CheckScopes to report read:workout, got %v", err)
+	}
+	if err := client.CheckScopes(ServiceCycle); err != nil {
+		t.Errorf("expected CheckScopes to pass, got %v", err)
+	}
+
+	if _, err := client.Cycle.List(ctx, nil); err != nil {
+		t.Errorf("expected a granted scope to pass, got %v", err)
+	}
+	if _, err := newMockClient(ts).Sleep.List(ctx, nil); err != nil {
+		t.Errorf("expected no checks without WithScopes, got %v", err)
+	}
+	// A token response without a scope parameter grants what was requested.
+	if _, err := newMockClient(ts, WithScopes(ParseScopes("")...)).Sleep.List(ctx, nil); err != nil {
+		t.Errorf("expected no checks for an empty grant, got %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
@@ -0,0 +1,65 @@
+package whoop
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ScoreState describes whether WHOOP has finished calculating the score of a
+// Cycle, Sleep, Workout or Recovery.
+type ScoreState string
+
+const (
+	// ScoreStateScored indicates the score has been calculated and is populated.
+	ScoreStateScored ScoreState = "SCORED"
+
+	// ScoreStatePendingScore indicates WHOOP is still calculating the score.
+	ScoreStatePendingScore ScoreState = "PENDING_SCORE"
+
+	// ScoreStateUnscorable indicates WHOOP cannot calculate a score for the record.
+	ScoreStateUnscorable ScoreState = "UNSCORABLE"
+)
+
+// defaultScorePollInterval is used by WaitForScore when a non-positive poll interval is given.
+const defaultScorePollInterval = 5 * time.Second
+
+// IsScored reports whether the state is ScoreStateScored.
+func (s ScoreState) IsScored() bool {
+	return s == ScoreStateScored
+}
+
+// IsFinal reports whether the state will no longer change, i.e. the record is
+// either scored or unscorable.
+func (s ScoreState) IsFinal() bool {
+	return s == ScoreStateScored || s == ScoreStateUnscorable
+}
+
+// waitForScore polls fetch every interval until the fetched record reaches a
+// final ScoreState, fetch fails, or ctx is done.
+func waitForScore[T any](ctx context.Context, interval time.Duration, fetch func(context.Context) (*T, error), state func(*T) ScoreState) (*T, error) {
+	if interval <= 0 {
+		interval = defaultScorePollInterval
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context canceled while waiting for score: %w", ctx.Err())
+		}
+
+		item, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if state(item).IsFinal() {
+			return item, nil
+		}
+
+		timer.Reset(interval)
+	}
+}
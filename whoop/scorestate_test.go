@@ -0,0 +1,137 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScoreState_Helpers(t *testing.T) {
+	tests := []struct {
+		state  ScoreState
+		scored bool
+		final  bool
+	}{
+		{ScoreStateScored, true, true},
+		{ScoreStatePendingScore, false, false},
+		{ScoreStateUnscorable, false, true},
+		{ScoreState(""), false, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.IsScored(); got != tt.scored {
+			t.Errorf("%q.IsScored() = %v, want %v", tt.state, got, tt.scored)
+		}
+		if got := tt.state.IsFinal(); got != tt.final {
+			t.Errorf("%q.IsFinal() = %v, want %v", tt.state, got, tt.final)
+		}
+	}
+}
+
+func TestModels_IsScored(t *testing.T) {
+	if !(&Cycle{ScoreState: ScoreStateScored, Score: &Score{}}).IsScored() {
+		t.Error("expected scored cycle with score to be scored")
+	}
+	if (&Cycle{ScoreState: ScoreStateScored}).IsScored() {
+		t.Error("expected cycle without score to not be scored")
+	}
+	if (&Sleep{ScoreState: ScoreStatePendingScore, Score: &SleepScore{}}).IsScored() {
+		t.Error("expected pending sleep to not be scored")
+	}
+	if !(&Workout{ScoreState: ScoreStateScored, Score: &WorkoutScore{}}).IsScored() {
+		t.Error("expected scored workout to be scored")
+	}
+	if (&Recovery{ScoreState: ScoreStateUnscorable}).IsScored() {
+		t.Error("expected unscorable recovery to not be scored")
+	}
+}
+
+// newScoringServer returns a server that reports PENDING_SCORE for the first
+// pending requests to path and finalState afterwards.
+func newScoringServer(t *testing.T, path string, pending int32, finalState ScoreState, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		state := ScoreStatePendingScore
+		if calls.Add(1) > pending {
+			state = finalState
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id": "wkt-1", "cycle_id": 42, "score_state": %q}`, state)
+	}))
+}
+
+func TestWorkoutService_WaitForScore(t *testing.T) {
+	var calls atomic.Int32
+	ts := newScoringServer(t, "/activity/workout/wkt-1", 2, ScoreStateScored, &calls)
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL))
+
+	workout, err := client.Workout.WaitForScore(context.Background(), "wkt-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workout.ScoreState != ScoreStateScored {
+		t.Errorf("expected SCORED, got %s", workout.ScoreState)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 polls, got %d", calls.Load())
+	}
+}
+
+func TestRecoveryService_WaitForScore_Unscorable(t *testing.T) {
+	var calls atomic.Int32
+	ts := newScoringServer(t, "/cycle/42/recovery", 1, ScoreStateUnscorable, &calls)
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL))
+
+	recovery, err := client.Recovery.WaitForScore(context.Background(), 42, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recovery.ScoreState != ScoreStateUnscorable {
+		t.Errorf("expected UNSCORABLE, got %s", recovery.ScoreState)
+	}
+}
+
+func TestWaitForScore_ContextCanceled(t *testing.T) {
+	var calls atomic.Int32
+	ts := newScoringServer(t, "/activity/sleep/wkt-1", 1<<30, ScoreStateScored, &calls)
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL), WithRateLimiting(false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Sleep.WaitForScore(ctx, "wkt-1", 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWaitForScore_FetchError(t *testing.T) {
+	ts := newMockServer(t)
+	defer ts.Close()
+
+	client := newMockClient(ts)
+
+	_, err := client.Cycle.WaitForScore(context.Background(), 999999, time.Millisecond)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+}
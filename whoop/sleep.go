@@ -2,6 +2,7 @@ package whoop
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
@@ -19,8 +20,16 @@ type Sleep struct {
 	End            time.Time   `json:"end"`
 	TimezoneOffset string      `json:"timezone_offset"`
 	Nap            bool        `json:"nap"`
-	ScoreState     string      `json:"score_state"`
+	ScoreState     ScoreState  `json:"score_state"`
 	Score          *SleepScore `json:"score,omitempty"`
+
+	// Extra holds the JSON members not mapped to a field of Sleep.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IsScored reports whether the sleep event has been scored and its Score is populated.
+func (s *Sleep) IsScored() bool {
+	return s.ScoreState.IsScored() && s.Score != nil
 }
 
 // SleepScore provides calculated metrics for a Sleep.
@@ -31,6 +40,9 @@ type SleepScore struct {
 	SleepPerformancePercentage float64       `json:"sleep_performance_percentage"`
 	SleepConsistencyPercentage float64       `json:"sleep_consistency_percentage"`
 	SleepEfficiencyPercentage  float64       `json:"sleep_efficiency_percentage"`
+
+	// Extra holds the JSON members not mapped to a field of SleepScore.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // StageSummary breaks down durations spent in different sleep stages.
@@ -43,6 +55,9 @@ type StageSummary struct {
 	TotalRemSleepTimeMilli      int `json:"total_rem_sleep_time_milli"`
 	SleepCycleCount             int `json:"sleep_cycle_count"`
 	DisturbanceCount            int `json:"disturbance_count"`
+
+	// Extra holds the JSON members not mapped to a field of StageSummary.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SleepNeeded defines baseline and calculated sleep needs for the individual.
@@ -51,8 +66,14 @@ type SleepNeeded struct {
 	NeedFromSleepDebtMilli    int `json:"need_from_sleep_debt_milli"`
 	NeedFromRecentStrainMilli int `json:"need_from_recent_strain_milli"`
 	NeedFromRecentNapMilli    int `json:"need_from_recent_nap_milli"`
+
+	// Extra holds the JSON members not mapped to a field of SleepNeeded.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// SleepPath is the API path of the sleep collection.
+const SleepPath = "/activity/sleep"
+
 // SleepService handles communication with the sleep related methods.
 type SleepService struct {
 	client *Client
@@ -60,17 +81,40 @@ type SleepService struct {
 
 // GetByID fetches a single sleep event by its UUID.
 func (s *SleepService) GetByID(ctx context.Context, id string) (*Sleep, error) {
+	if err := s.client.requireScope(ScopeReadSleep); err != nil {
+		return nil, err
+	}
 	var item Sleep
-	if err := s.client.Get(ctx, fmt.Sprintf("/activity/sleep/%s", url.PathEscape(id)), &item); err != nil {
+	if err := s.client.Get(ctx, fmt.Sprintf(SleepPath+"/%s", url.PathEscape(id)), &item); err != nil {
 		return nil, err
 	}
 
 	return &item, nil
 }
 
+// GetMany fetches multiple sleep events by ID concurrently, bounded by the client's
+// batch concurrency. Per-ID failures, including 404s for unknown IDs, are
+// reported in the result's Errors map without failing the whole batch.
+func (s *SleepService) GetMany(ctx context.Context, ids []string) *BatchResult[string, Sleep] {
+	return getMany(ctx, s.client.batchConcurrency, ids, s.GetByID)
+}
+
+// WaitForScore polls GetByID every pollInterval until the sleep event is scored or
+// unscorable, which is typically needed right after a webhook event arrives.
+// A non-positive pollInterval defaults to 5 seconds.
+func (s *SleepService) WaitForScore(ctx context.Context, id string, pollInterval time.Duration) (*Sleep, error) {
+	return waitForScore(ctx, pollInterval,
+		func(ctx context.Context) (*Sleep, error) { return s.GetByID(ctx, id) },
+		func(item *Sleep) ScoreState { return item.ScoreState },
+	)
+}
+
 // List fetches a paginated collection of sleep events.
 func (s *SleepService) List(ctx context.Context, opts *ListOptions) (*SleepPage, error) {
-	page, err := getPaginated[Sleep](ctx, s.client, "/activity/sleep", opts)
+	if err := s.client.requireScope(ScopeReadSleep); err != nil {
+		return nil, err
+	}
+	page, err := getPaginated[Sleep](ctx, s.client, SleepPath, opts)
 	if err != nil {
 		return nil, err
 	}
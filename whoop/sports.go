@@ -0,0 +1,250 @@
+package whoop
+
+import (
+	"slices"
+	"strings"
+)
+
+// SportCategory groups sports by the kind of training they represent.
+type SportCategory string
+
+const (
+	// SportCategoryCardio covers endurance activities such as running, cycling and swimming.
+	SportCategoryCardio SportCategory = "cardio"
+
+	// SportCategoryStrength covers resistance and high-intensity training.
+	SportCategoryStrength SportCategory = "strength"
+
+	// SportCategoryTeam covers team and field sports.
+	SportCategoryTeam SportCategory = "team"
+
+	// SportCategoryRacket covers racket and paddle sports.
+	SportCategoryRacket SportCategory = "racket"
+
+	// SportCategoryCombat covers martial arts and combat sports.
+	SportCategoryCombat SportCategory = "combat"
+
+	// SportCategoryMindfulness covers yoga, meditation and similar practices.
+	SportCategoryMindfulness SportCategory = "mindfulness"
+
+	// SportCategoryRecovery covers recovery modalities such as sauna and massage.
+	SportCategoryRecovery SportCategory = "recovery"
+
+	// SportCategoryLifestyle covers everyday activities that WHOOP can track,
+	// such as yard work, commuting or parenting.
+	SportCategoryLifestyle SportCategory = "lifestyle"
+
+	// SportCategoryOther covers generic activities and sports not in the catalog.
+	SportCategoryOther SportCategory = "other"
+)
+
+// Sport describes an entry in the WHOOP sport catalog.
+type Sport struct {
+	ID       int
+	Name     string
+	Category SportCategory
+
+	// Distance reports whether the sport typically records a distance.
+	Distance bool
+}
+
+// sportCatalog lists the sports known to the WHOOP API, keyed by sport ID.
+var sportCatalog = map[int]Sport{
+	-1:  {ID: -1, Name: "Activity", Category: SportCategoryOther},
+	0:   {ID: 0, Name: "Running", Category: SportCategoryCardio, Distance: true},
+	1:   {ID: 1, Name: "Cycling", Category: SportCategoryCardio, Distance: true},
+	16:  {ID: 16, Name: "Baseball", Category: SportCategoryTeam},
+	17:  {ID: 17, Name: "Basketball", Category: SportCategoryTeam},
+	18:  {ID: 18, Name: "Rowing", Category: SportCategoryCardio, Distance: true},
+	19:  {ID: 19, Name: "Fencing", Category: SportCategoryCombat},
+	20:  {ID: 20, Name: "Field Hockey", Category: SportCategoryTeam},
+	21:  {ID: 21, Name: "Football", Category: SportCategoryTeam},
+	22:  {ID: 22, Name: "Golf", Category: SportCategoryOther, Distance: true},
+	24:  {ID: 24, Name: "Ice Hockey", Category: SportCategoryTeam},
+	25:  {ID: 25, Name: "Lacrosse", Category: SportCategoryTeam},
+	27:  {ID: 27, Name: "Rugby", Category: SportCategoryTeam},
+	28:  {ID: 28, Name: "Sailing", Category: SportCategoryOther, Distance: true},
+	29:  {ID: 29, Name: "Skiing", Category: SportCategoryCardio, Distance: true},
+	30:  {ID: 30, Name: "Soccer", Category: SportCategoryTeam},
+	31:  {ID: 31, Name: "Softball", Category: SportCategoryTeam},
+	32:  {ID: 32, Name: "Squash", Category: SportCategoryRacket},
+	33:  {ID: 33, Name: "Swimming", Category: SportCategoryCardio, Distance: true},
+	34:  {ID: 34, Name: "Tennis", Category: SportCategoryRacket},
+	35:  {ID: 35, Name: "Track & Field", Category: SportCategoryCardio, Distance: true},
+	36:  {ID: 36, Name: "Volleyball", Category: SportCategoryTeam},
+	37:  {ID: 37, Name: "Water Polo", Category: SportCategoryTeam},
+	38:  {ID: 38, Name: "Wrestling", Category: SportCategoryCombat},
+	39:  {ID: 39, Name: "Boxing", Category: SportCategoryCombat},
+	42:  {ID: 42, Name: "Dance", Category: SportCategoryCardio},
+	43:  {ID: 43, Name: "Pilates", Category: SportCategoryStrength},
+	44:  {ID: 44, Name: "Yoga", Category: SportCategoryMindfulness},
+	45:  {ID: 45, Name: "Weightlifting", Category: SportCategoryStrength},
+	47:  {ID: 47, Name: "Cross Country Skiing", Category: SportCategoryCardio, Distance: true},
+	48:  {ID: 48, Name: "Functional Fitness", Category: SportCategoryStrength},
+	49:  {ID: 49, Name: "Duathlon", Category: SportCategoryCardio, Distance: true},
+	51:  {ID: 51, Name: "Gymnastics", Category: SportCategoryStrength},
+	52:  {ID: 52, Name: "Hiking/Rucking", Category: SportCategoryCardio, Distance: true},
+	53:  {ID: 53, Name: "Horseback Riding", Category: SportCategoryOther, Distance: true},
+	55:  {ID: 55, Name: "Kayaking", Category: SportCategoryCardio, Distance: true},
+	56:  {ID: 56, Name: "Martial Arts", Category: SportCategoryCombat},
+	57:  {ID: 57, Name: "Mountain Biking", Category: SportCategoryCardio, Distance: true},
+	59:  {ID: 59, Name: "Powerlifting", Category: SportCategoryStrength},
+	60:  {ID: 60, Name: "Rock Climbing", Category: SportCategoryStrength},
+	61:  {ID: 61, Name: "Paddleboarding", Category: SportCategoryCardio, Distance: true},
+	62:  {ID: 62, Name: "Triathlon", Category: SportCategoryCardio, Distance: true},
+	63:  {ID: 63, Name: "Walking", Category: SportCategoryCardio, Distance: true},
+	64:  {ID: 64, Name: "Surfing", Category: SportCategoryOther},
+	65:  {ID: 65, Name: "Elliptical", Category: SportCategoryCardio},
+	66:  {ID: 66, Name: "Stairmaster", Category: SportCategoryCardio},
+	70:  {ID: 70, Name: "Meditation", Category: SportCategoryMindfulness},
+	71:  {ID: 71, Name: "Other", Category: SportCategoryOther},
+	73:  {ID: 73, Name: "Diving", Category: SportCategoryOther},
+	74:  {ID: 74, Name: "Operations - Tactical", Category: SportCategoryOther},
+	75:  {ID: 75, Name: "Operations - Medical", Category: SportCategoryOther},
+	76:  {ID: 76, Name: "Operations - Flying", Category: SportCategoryOther},
+	77:  {ID: 77, Name: "Operations - Water", Category: SportCategoryOther},
+	82:  {ID: 82, Name: "Ultimate", Category: SportCategoryTeam},
+	83:  {ID: 83, Name: "Climber", Category: SportCategoryCardio},
+	84:  {ID: 84, Name: "Jumping Rope", Category: SportCategoryCardio},
+	85:  {ID: 85, Name: "Australian Football", Category: SportCategoryTeam},
+	86:  {ID: 86, Name: "Skateboarding", Category: SportCategoryOther, Distance: true},
+	87:  {ID: 87, Name: "Coaching", Category: SportCategoryOther},
+	88:  {ID: 88, Name: "Ice Bath", Category: SportCategoryRecovery},
+	89:  {ID: 89, Name: "Commuting", Category: SportCategoryLifestyle},
+	90:  {ID: 90, Name: "Gaming", Category: SportCategoryLifestyle},
+	91:  {ID: 91, Name: "Snowboarding", Category: SportCategoryOther, Distance: true},
+	92:  {ID: 92, Name: "Motocross", Category: SportCategoryOther},
+	93:  {ID: 93, Name: "Caddying", Category: SportCategoryLifestyle, Distance: true},
+	94:  {ID: 94, Name: "Obstacle Course Racing", Category: SportCategoryCardio, Distance: true},
+	95:  {ID: 95, Name: "Motor Racing", Category: SportCategoryOther},
+	96:  {ID: 96, Name: "HIIT", Category: SportCategoryStrength},
+	97:  {ID: 97, Name: "Spin", Category: SportCategoryCardio},
+	98:  {ID: 98, Name: "Jiu Jitsu", Category: SportCategoryCombat},
+	99:  {ID: 99, Name: "Manual Labor", Category: SportCategoryLifestyle},
+	100: {ID: 100, Name: "Cricket", Category: SportCategoryTeam},
+	101: {ID: 101, Name: "Pickleball", Category: SportCategoryRacket},
+	102: {ID: 102, Name: "Inline Skating", Category: SportCategoryCardio, Distance: true},
+	103: {ID: 103, Name: "Box Fitness", Category: SportCategoryStrength},
+	104: {ID: 104, Name: "Spikeball", Category: SportCategoryTeam},
+	105: {ID: 105, Name: "Wheelchair Pushing", Category: SportCategoryCardio, Distance: true},
+	106: {ID: 106, Name: "Paddle Tennis", Category: SportCategoryRacket},
+	107: {ID: 107, Name: "Barre", Category: SportCategoryStrength},
+	108: {ID: 108, Name: "Stage Performance", Category: SportCategoryLifestyle},
+	109: {ID: 109, Name: "High Stress Work", Category: SportCategoryLifestyle},
+	110: {ID: 110, Name: "Parkour", Category: SportCategoryCardio},
+	111: {ID: 111, Name: "Gaelic Football", Category: SportCategoryTeam},
+	112: {ID: 112, Name: "Hurling/Camogie", Category: SportCategoryTeam},
+	113: {ID: 113, Name: "Circus Arts", Category: SportCategoryOther},
+	121: {ID: 121, Name: "Massage Therapy", Category: SportCategoryRecovery},
+	123: {ID: 123, Name: "Strength Trainer", Category: SportCategoryStrength},
+	125: {ID: 125, Name: "Watching Sports", Category: SportCategoryLifestyle},
+	126: {ID: 126, Name: "Assault Bike", Category: SportCategoryCardio},
+	127: {ID: 127, Name: "Kickboxing", Category: SportCategoryCombat},
+	128: {ID: 128, Name: "Stretching", Category: SportCategoryMindfulness},
+	230: {ID: 230, Name: "Table Tennis", Category: SportCategoryRacket},
+	231: {ID: 231, Name: "Badminton", Category: SportCategoryRacket},
+	232: {ID: 232, Name: "Netball", Category: SportCategoryTeam},
+	233: {ID: 233, Name: "Sauna", Category: SportCategoryRecovery},
+	234: {ID: 234, Name: "Disc Golf", Category: SportCategoryOther, Distance: true},
+	235: {ID: 235, Name: "Yard Work", Category: SportCategoryLifestyle},
+	236: {ID: 236, Name: "Air Compression", Category: SportCategoryRecovery},
+	237: {ID: 237, Name: "Percussive Massage", Category: SportCategoryRecovery},
+	238: {ID: 238, Name: "Paintball", Category: SportCategoryOther},
+	239: {ID: 239, Name: "Ice Skating", Category: SportCategoryCardio, Distance: true},
+	240: {ID: 240, Name: "Handball", Category: SportCategoryTeam},
+	248: {ID: 248, Name: "F45 Training", Category: SportCategoryStrength},
+	249: {ID: 249, Name: "Padel", Category: SportCategoryRacket},
+	250: {ID: 250, Name: "Barry's", Category: SportCategoryStrength},
+	251: {ID: 251, Name: "Dedicated Parenting", Category: SportCategoryLifestyle},
+	252: {ID: 252, Name: "Stroller Walking", Category: SportCategoryCardio, Distance: true},
+	253: {ID: 253, Name: "Stroller Jogging", Category: SportCategoryCardio, Distance: true},
+	254: {ID: 254, Name: "Toddlerwearing", Category: SportCategoryLifestyle},
+	255: {ID: 255, Name: "Babywearing", Category: SportCategoryLifestyle},
+	258: {ID: 258, Name: "Barre3", Category: SportCategoryStrength},
+	259: {ID: 259, Name: "Hot Yoga", Category: SportCategoryMindfulness},
+	261: {ID: 261, Name: "Stadium Steps", Category: SportCategoryCardio},
+	262: {ID: 262, Name: "Polo", Category: SportCategoryTeam},
+	263: {ID: 263, Name: "Musical Performance", Category: SportCategoryLifestyle},
+	264: {ID: 264, Name: "Kite Boarding", Category: SportCategoryOther, Distance: true},
+	266: {ID: 266, Name: "Dog Walking", Category: SportCategoryCardio, Distance: true},
+	267: {ID: 267, Name: "Water Skiing", Category: SportCategoryOther, Distance: true},
+	268: {ID: 268, Name: "Wakeboarding", Category: SportCategoryOther},
+	269: {ID: 269, Name: "Cooking", Category: SportCategoryLifestyle},
+	270: {ID: 270, Name: "Cleaning", Category: SportCategoryLifestyle},
+	272: {ID: 272, Name: "Public Speaking", Category: SportCategoryLifestyle},
+}
+
+// sportsByName indexes the catalog by normalized sport name.
+var sportsByName = func() map[string]Sport {
+	m := make(map[string]Sport, len(sportCatalog))
+	for _, s := range sportCatalog {
+		m[normalizeSportName(s.Name)] = s
+	}
+	return m
+}()
+
+// normalizeSportName lowercases a sport name and folds the separators used by
+// the API ("functional-fitness", "functional_fitness") into single spaces.
+func normalizeSportName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// SportByID looks up a sport in the catalog by its WHOOP sport ID.
+func SportByID(id int) (Sport, bool) {
+	s, ok := sportCatalog[id]
+	return s, ok
+}
+
+// SportByName looks up a sport in the catalog by name. Matching is
+// case-insensitive and treats hyphens and underscores as spaces, so both
+// "Functional Fitness" and "functional-fitness" resolve to the same sport.
+func SportByName(name string) (Sport, bool) {
+	s, ok := sportsByName[normalizeSportName(name)]
+	return s, ok
+}
+
+// Sports returns every sport in the catalog, ordered by ID.
+func Sports() []Sport {
+	out := make([]Sport, 0, len(sportCatalog))
+	for _, s := range sportCatalog {
+		out = append(out, s)
+	}
+	slices.SortFunc(out, func(a, b Sport) int { return a.ID - b.ID })
+	return out
+}
+
+// SportsInCategory returns every sport in the given category, ordered by ID.
+func SportsInCategory(category SportCategory) []Sport {
+	return slices.DeleteFunc(Sports(), func(s Sport) bool { return s.Category != category })
+}
+
+// Sport returns the catalog entry for the workout's SportID. Sports missing
+// from the catalog are reported with the workout's SportName and SportCategoryOther.
+func (w *Workout) Sport() Sport {
+	if s, ok := SportByID(w.SportID); ok {
+		return s
+	}
+	return Sport{ID: w.SportID, Name: w.SportName, Category: SportCategoryOther}
+}
+
+// WorkoutFilter selects workouts by sport on the client side. The WHOOP API
+// does not support sport filtering, so filtered pages are fetched in full and
+// trimmed locally. A workout matches if its sport ID is listed in SportIDs or
+// its category is listed in Categories. An empty filter matches every workout.
+type WorkoutFilter struct {
+	SportIDs   []int
+	Categories []SportCategory
+}
+
+// Match reports whether the workout satisfies the filter.
+func (f *WorkoutFilter) Match(w *Workout) bool {
+	if f == nil || (len(f.SportIDs) == 0 && len(f.Categories) == 0) {
+		return true
+	}
+	if slices.Contains(f.SportIDs, w.SportID) {
+		return true
+	}
+	return slices.Contains(f.Categories, w.Sport().Category)
+}
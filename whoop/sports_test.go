@@ -0,0 +1,156 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSportByID(t *testing.T) {
+	s, ok := SportByID(0)
+	if !ok {
+		t.Fatal("expected sport 0 to be in the catalog")
+	}
+	if s.Name != "Running" || s.Category != SportCategoryCardio || !s.Distance {
+		t.Errorf("unexpected sport 0: %+v", s)
+	}
+
+	if _, ok := SportByID(99999); ok {
+		t.Error("expected unknown sport ID to be missing")
+	}
+}
+
+func TestSportByName(t *testing.T) {
+	for _, name := range []string{"Functional Fitness", "functional-fitness", "FUNCTIONAL_FITNESS", " functional  fitness "} {
+		s, ok := SportByName(name)
+		if !ok || s.ID != 48 {
+			t.Errorf("SportByName(%q) = %+v, %v; want ID 48", name, s, ok)
+		}
+	}
+
+	if _, ok := SportByName("quidditch"); ok {
+		t.Error("expected unknown sport name to be missing")
+	}
+}
+
+func TestSports_CatalogConsistency(t *testing.T) {
+	all := Sports()
+	if len(all) != len(sportCatalog) {
+		t.Fatalf("expected %d sports, got %d", len(sportCatalog), len(all))
+	}
+
+	names := make(map[string]int)
+	for i, s := range all {
+		if i > 0 && all[i-1].ID >= s.ID {
+			t.Errorf("sports not sorted by ID at index %d", i)
+		}
+		if s.Category == "" {
+			t.Errorf("sport %d has no category", s.ID)
+		}
+		if prev, dup := names[normalizeSportName(s.Name)]; dup {
+			t.Errorf("sports %d and %d share the name %q", prev, s.ID, s.Name)
+		}
+		names[normalizeSportName(s.Name)] = s.ID
+	}
+
+	for _, s := range SportsInCategory(SportCategoryMindfulness) {
+		if s.Category != SportCategoryMindfulness {
+			t.Errorf("unexpected category for sport %d: %s", s.ID, s.Category)
+		}
+	}
+}
+
+func TestWorkout_Sport(t *testing.T) {
+	w := &Workout{SportID: 44, SportName: "yoga"}
+	if got := w.Sport(); got.Name != "Yoga" || got.Category != SportCategoryMindfulness {
+		t.Errorf("unexpected sport: %+v", got)
+	}
+
+	unknown := &Workout{SportID: 9001, SportName: "new-sport"}
+	got := unknown.Sport()
+	if got.ID != 9001 || got.Name != "new-sport" || got.Category != SportCategoryOther {
+		t.Errorf("unexpected fallback sport: %+v", got)
+	}
+}
+
+func TestWorkoutFilter_Match(t *testing.T) {
+	run := &Workout{SportID: 0}
+	lift := &Workout{SportID: 45}
+	yoga := &Workout{SportID: 44}
+
+	tests := []struct {
+		name   string
+		filter *WorkoutFilter
+		want   []bool
+	}{
+		{"nil filter", nil, []bool{true, true, true}},
+		{"empty filter", &WorkoutFilter{}, []bool{true, true, true}},
+		{"by sport", &WorkoutFilter{SportIDs: []int{45}}, []bool{false, true, false}},
+		{"by category", &WorkoutFilter{Categories: []SportCategory{SportCategoryCardio}}, []bool{true, false, false}},
+		{"either", &WorkoutFilter{SportIDs: []int{44}, Categories: []SportCategory{SportCategoryStrength}}, []bool{false, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, w := range []*Workout{run, lift, yoga} {
+				if got := tt.filter.Match(w); got != tt.want[i] {
+					t.Errorf("Match(sport %d) = %v, want %v", w.SportID, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWorkoutService_ListFiltered(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("nextToken") {
+		case "":
+			_, _ = w.Write([]byte(`{
+				"records": [{"id": "a", "sport_id": 0}, {"id": "b", "sport_id": 45}, {"id": "c", "sport_id": 1}],
+				"next_token": "p2"
+			}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{
+				"records": [{"id": "d", "sport_id": 44}, {"id": "e", "sport_id": 63}],
+				"next_token": ""
+			}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL))
+	ctx := context.Background()
+
+	filter := WorkoutFilter{Categories: []SportCategory{SportCategoryCardio}}
+	page, err := client.Workout.ListFiltered(ctx, &ListOptions{Limit: 3}, filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for {
+		for _, w := range page.Records {
+			ids = append(ids, w.ID)
+		}
+		page, err = page.NextPage(ctx)
+		if errors.Is(err, ErrNoNextPage) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"a", "c", "e"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, ids)
+		}
+	}
+}
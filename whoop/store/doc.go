@@ -0,0 +1,70 @@
+// Package store persists WHOOP records, either in a local file so that
+// analytics can run offline, without requests to the API, or in a SQL database.
+//
+// A Store is an append-only log of JSON lines with an in-memory index. Every
+// upsert or delete appends one line; the index maps each live record to the
+// offset of its latest line, so reads seek straight to it. Opening a store
+// replays the log to rebuild the index, and Compact rewrites the log with
+// only the live records once superseded lines pile up. The store is pure Go
+// and needs no cgo or external database.
+//
+// Records are kept per user and keyed by their ID: cycles and workouts by
+// their own IDs, sleeps by UUID, recoveries by cycle ID, and profiles and body
+// measurements once per user. Upserts of a record older than the stored one,
+// by UpdatedAt, are ignored, so replaying an old sync never undoes a newer one:
+//
+//	s, err := store.Open("whoop.log")
+//	if err != nil {
+//	    return err
+//	}
+//	defer s.Close()
+//
+//	page, err := client.Sleep.List(ctx, &whoop.ListOptions{Start: &since})
+//	for err == nil {
+//	    for i := range page.Records {
+//	        if err := s.PutSleep(&page.Records[i]); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    page, err = page.NextPage(ctx)
+//	}
+//
+// # Queries
+//
+// Range queries return a user's records whose start time falls within a
+// half-open interval, in start order. Recoveries have no start time and are
+// ranged by CreatedAt instead:
+//
+//	sleeps, err := s.Sleeps(userID, from, to)
+//	recoveries, err := s.Recoveries(userID, from, to)
+//
+// # Webhooks
+//
+// ApplyWebhook keeps a store current from webhook events: deletions remove
+// the record, and updates fetch it with the client and upsert it:
+//
+//	event, err := whoop.ParseWebhook(r, secret)
+//	if err != nil {
+//	    return err
+//	}
+//	err = s.ApplyWebhook(ctx, client, event)
+//
+// # SQL Databases
+//
+// SQLSink, like Store.Sink, implements Sink. It writes records to a
+// PostgreSQL-compatible database through database/sql, with one table per
+// resource and scores flattened into nullable columns. Migrate creates the
+// tables and records the schema version in schema_migrations, holding an
+// advisory lock so that processes starting together do not race. Upserts are
+// keyed by record ID and never replace a row with an older UpdatedAt:
+//
+//	db, err := sql.Open("pgx", os.Getenv("DATABASE_URL"))
+//	if err != nil {
+//	    return err
+//	}
+//	sink := store.NewSQLSink(db)
+//	if err := sink.Migrate(ctx); err != nil {
+//	    return err
+//	}
+//	err = store.ApplyWebhook(ctx, client, sink, event)
+package store
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// ErrUnsupportedEvent is returned by ApplyWebhook for event types it does not
+// know how to apply.
+var ErrUnsupportedEvent = errors.New("unsupported webhook event")
+
+// Sink receives synced records and deletions. Puts are upserts keyed by the
+// record's ID, and must not replace a record with one updated earlier, so
+// that redelivered or reordered writes are harmless. Deleting a record that
+// does not exist is not an error.
+type Sink interface {
+	PutCycle(ctx context.Context, c *whoop.Cycle) error
+	PutSleep(ctx context.Context, s *whoop.Sleep) error
+	PutWorkout(ctx context.Context, w *whoop.Workout) error
+	PutRecovery(ctx context.Context, r *whoop.Recovery) error
+	PutProfile(ctx context.Context, p *whoop.BasicProfile) error
+	PutBodyMeasurement(ctx context.Context, userID int, m *whoop.BodyMeasurement) error
+
+	DeleteSleep(ctx context.Context, userID int, id string) error
+	DeleteWorkout(ctx context.Context, userID int, id string) error
+
+	// DeleteRecovery deletes the recovery of the given sleep, which is how
+	// recovery webhook events identify it.
+	DeleteRecovery(ctx context.Context, userID int, sleepID string) error
+}
+
+// ApplyWebhook applies a webhook event to sink. Deletion events delete the
+// record. Update events fetch the record with client and put it; a recovery
+// event carries the ID of the recovery's sleep, so the sleep is fetched first
+// to find its cycle. Events of other types return ErrUnsupportedEvent.
+func ApplyWebhook(ctx context.Context, client *whoop.Client, sink Sink, event *whoop.WebhookEvent) error {
+	switch event.Type {
+	case "workout.updated":
+		w, err := client.Workout.GetByID(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		return sink.PutWorkout(ctx, w)
+	case "sleep.updated":
+		sl, err := client.Sleep.GetByID(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		return sink.PutSleep(ctx, sl)
+	case "recovery.updated":
+		sl, err := client.Sleep.GetByID(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		r, err := client.Recovery.GetByID(ctx, sl.CycleID)
+		if err != nil {
+			return err
+		}
+		return sink.PutRecovery(ctx, r)
+	case "workout.deleted":
+		return sink.DeleteWorkout(ctx, event.UserID, event.ID)
+	case "sleep.deleted":
+		return sink.DeleteSleep(ctx, event.UserID, event.ID)
+	case "recovery.deleted":
+		return sink.DeleteRecovery(ctx, event.UserID, event.ID)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedEvent, event.Type)
+	}
+}
+
+// Sink returns a Sink that writes to the store. The store does no I/O that
+// can be canceled, so contexts are ignored.
+func (s *Store) Sink() Sink {
+	return storeSink{s}
+}
+
+// storeSink adapts a Store to the Sink interface.
+type storeSink struct {
+	s *Store
+}
+
+func (a storeSink) PutCycle(_ context.Context, c *whoop.Cycle) error       { return a.s.PutCycle(c) }
+func (a storeSink) PutSleep(_ context.Context, sl *whoop.Sleep) error      { return a.s.PutSleep(sl) }
+func (a storeSink) PutWorkout(_ context.Context, w *whoop.Workout) error   { return a.s.PutWorkout(w) }
+func (a storeSink) PutRecovery(_ context.Context, r *whoop.Recovery) error { return a.s.PutRecovery(r) }
+
+func (a storeSink) PutProfile(_ context.Context, p *whoop.BasicProfile) error {
+	return a.s.PutProfile(p)
+}
+
+func (a storeSink) PutBodyMeasurement(_ context.Context, userID int, m *whoop.BodyMeasurement) error {
+	return a.s.PutBodyMeasurement(userID, m)
+}
+
+func (a storeSink) DeleteSleep(_ context.Context, userID int, id string) error {
+	return a.s.Delete(userID, KindSleep, id)
+}
+
+func (a storeSink) DeleteWorkout(_ context.Context, userID int, id string) error {
+	return a.s.Delete(userID, KindWorkout, id)
+}
+
+func (a storeSink) DeleteRecovery(_ context.Context, userID int, sleepID string) error {
+	return a.s.deleteRecoveryBySleep(userID, sleepID)
+}
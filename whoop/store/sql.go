@@ -0,0 +1,366 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// migrations are the schema versions applied by SQLSink.Migrate, in order.
+// Version n is migrations[n-1]. Applied migrations must never change; add a
+// new version instead.
+var migrations = [][]string{
+	{
+		`CREATE TABLE whoop_cycles (
+			id BIGINT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			start_time TIMESTAMPTZ NOT NULL,
+			end_time TIMESTAMPTZ,
+			timezone_offset TEXT NOT NULL,
+			score_state TEXT NOT NULL,
+			strain DOUBLE PRECISION,
+			kilojoule DOUBLE PRECISION,
+			average_heart_rate INTEGER,
+			max_heart_rate INTEGER
+		)`,
+		`CREATE INDEX whoop_cycles_user_start ON whoop_cycles (user_id, start_time)`,
+		`CREATE TABLE whoop_sleeps (
+			id TEXT PRIMARY KEY,
+			cycle_id BIGINT NOT NULL,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			start_time TIMESTAMPTZ NOT NULL,
+			end_time TIMESTAMPTZ NOT NULL,
+			timezone_offset TEXT NOT NULL,
+			nap BOOLEAN NOT NULL,
+			score_state TEXT NOT NULL,
+			respiratory_rate DOUBLE PRECISION,
+			sleep_performance_percentage DOUBLE PRECISION,
+			sleep_consistency_percentage DOUBLE PRECISION,
+			sleep_efficiency_percentage DOUBLE PRECISION,
+			total_in_bed_time_milli BIGINT,
+			total_awake_time_milli BIGINT,
+			total_no_data_time_milli BIGINT,
+			total_light_sleep_time_milli BIGINT,
+			total_slow_wave_sleep_time_milli BIGINT,
+			total_rem_sleep_time_milli BIGINT,
+			sleep_cycle_count INTEGER,
+			disturbance_count INTEGER,
+			baseline_milli BIGINT,
+			need_from_sleep_debt_milli BIGINT,
+			need_from_recent_strain_milli BIGINT,
+			need_from_recent_nap_milli BIGINT
+		)`,
+		`CREATE INDEX whoop_sleeps_user_start ON whoop_sleeps (user_id, start_time)`,
+		`CREATE TABLE whoop_workouts (
+			id TEXT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			start_time TIMESTAMPTZ NOT NULL,
+			end_time TIMESTAMPTZ NOT NULL,
+			timezone_offset TEXT NOT NULL,
+			sport_id INTEGER NOT NULL,
+			sport_name TEXT NOT NULL,
+			score_state TEXT NOT NULL,
+			strain DOUBLE PRECISION,
+			average_heart_rate INTEGER,
+			max_heart_rate INTEGER,
+			kilojoule DOUBLE PRECISION,
+			percent_recorded DOUBLE PRECISION,
+			distance_meter DOUBLE PRECISION,
+			altitude_gain_meter DOUBLE PRECISION,
+			altitude_change_meter DOUBLE PRECISION,
+			zone_zero_milli BIGINT,
+			zone_one_milli BIGINT,
+			zone_two_milli BIGINT,
+			zone_three_milli BIGINT,
+			zone_four_milli BIGINT,
+			zone_five_milli BIGINT
+		)`,
+		`CREATE INDEX whoop_workouts_user_start ON whoop_workouts (user_id, start_time)`,
+		`CREATE TABLE whoop_recoveries (
+			cycle_id BIGINT PRIMARY KEY,
+			sleep_id TEXT NOT NULL,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			score_state TEXT NOT NULL,
+			user_calibrating BOOLEAN,
+			recovery_score DOUBLE PRECISION,
+			resting_heart_rate DOUBLE PRECISION,
+			hrv_rmssd_milli DOUBLE PRECISION,
+			spo2_percentage DOUBLE PRECISION,
+			skin_temp_celsius DOUBLE PRECISION
+		)`,
+		`CREATE INDEX whoop_recoveries_sleep ON whoop_recoveries (sleep_id)`,
+		`CREATE TABLE whoop_profiles (
+			user_id BIGINT PRIMARY KEY,
+			email TEXT NOT NULL,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL
+		)`,
+		`CREATE TABLE whoop_body_measurements (
+			user_id BIGINT PRIMARY KEY,
+			height_meter DOUBLE PRECISION NOT NULL,
+			weight_kilogram DOUBLE PRECISION NOT NULL,
+			max_heart_rate INTEGER NOT NULL
+		)`,
+	},
+}
+
+// SchemaVersion is the schema version SQLSink.Migrate brings a database to,
+// which is len(migrations).
+const SchemaVersion = 1
+
+// sqlTable describes the upsert of one resource table. The first column is
+// the conflict key.
+type sqlTable struct {
+	name      string
+	columns   []string
+	versioned bool // has updated_at, which guards against stale upserts
+}
+
+var (
+	cycleTable = sqlTable{"whoop_cycles", []string{
+		"id", "user_id", "created_at", "updated_at", "start_time", "end_time", "timezone_offset", "score_state",
+		"strain", "kilojoule", "average_heart_rate", "max_heart_rate",
+	}, true}
+	sleepTable = sqlTable{"whoop_sleeps", []string{
+		"id", "cycle_id", "user_id", "created_at", "updated_at", "start_time", "end_time", "timezone_offset", "nap", "score_state",
+		"respiratory_rate", "sleep_performance_percentage", "sleep_consistency_percentage", "sleep_efficiency_percentage",
+		"total_in_bed_time_milli", "total_awake_time_milli", "total_no_data_time_milli", "total_light_sleep_time_milli",
+		"total_slow_wave_sleep_time_milli", "total_rem_sleep_time_milli", "sleep_cycle_count", "disturbance_count",
+		"baseline_milli", "need_from_sleep_debt_milli", "need_from_recent_strain_milli", "need_from_recent_nap_milli",
+	}, true}
+	workoutTable = sqlTable{"whoop_workouts", []string{
+		"id", "user_id", "created_at", "updated_at", "start_time", "end_time", "timezone_offset", "sport_id", "sport_name", "score_state",
+		"strain", "average_heart_rate", "max_heart_rate", "kilojoule", "percent_recorded",
+		"distance_meter", "altitude_gain_meter", "altitude_change_meter",
+		"zone_zero_milli", "zone_one_milli", "zone_two_milli", "zone_three_milli", "zone_four_milli", "zone_five_milli",
+	}, true}
+	recoveryTable = sqlTable{"whoop_recoveries", []string{
+		"cycle_id", "sleep_id", "user_id", "created_at", "updated_at", "score_state",
+		"user_calibrating", "recovery_score", "resting_heart_rate", "hrv_rmssd_milli", "spo2_percentage", "skin_temp_celsius",
+	}, true}
+	profileTable = sqlTable{"whoop_profiles", []string{
+		"user_id", "email", "first_name", "last_name",
+	}, false}
+	bodyMeasurementTable = sqlTable{"whoop_body_measurements", []string{
+		"user_id", "height_meter", "weight_kilogram", "max_heart_rate",
+	}, false}
+)
+
+// upsert returns the INSERT ... ON CONFLICT statement for t. For versioned
+// tables the update only applies when the stored row is not newer.
+func (t sqlTable) upsert() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES (", t.name, strings.Join(t.columns, ", "))
+	for i := range t.columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "$%d", i+1)
+	}
+	fmt.Fprintf(&b, ") ON CONFLICT (%s) DO UPDATE SET ", t.columns[0])
+	for i, c := range t.columns[1:] {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s = excluded.%s", c, c)
+	}
+	if t.versioned {
+		fmt.Fprintf(&b, " WHERE %s.updated_at <= excluded.updated_at", t.name)
+	}
+	return b.String()
+}
+
+// SQLSink is a Sink that writes records to a Postgres-compatible database
+// through database/sql, one table per resource with scores flattened into
+// nullable columns. Call Migrate before use. The sink uses $n placeholders,
+// ON CONFLICT upserts and TIMESTAMPTZ columns, so it works with PostgreSQL and
+// databases that speak its dialect, such as CockroachDB.
+type SQLSink struct {
+	db *sql.DB
+}
+
+// NewSQLSink returns a sink that writes to db.
+func NewSQLSink(db *sql.DB) *SQLSink {
+	return &SQLSink{db: db}
+}
+
+// migrateLockKey is the PostgreSQL advisory lock key Migrate holds while it
+// reads and applies schema versions.
+const migrateLockKey int64 = 0x77686f6f70 // "whoop"
+
+// Migrate creates or upgrades the sink's tables to SchemaVersion. Applied
+// versions are recorded in the schema_migrations table. Each version is
+// applied in its own transaction holding a transaction-scoped advisory lock
+// (pg_advisory_xact_lock), so processes that start together wait for one
+// another and Migrate is safe to call on every start.
+func (s *SQLSink) Migrate(ctx context.Context) error {
+	for {
+		done, err := s.migrateNext(ctx)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// migrateNext applies the next pending schema version, if any, under the
+// migration lock. It reports done once the schema is at SchemaVersion.
+func (s *SQLSink) migrateNext(ctx context.Context) (done bool, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, migrateLockKey); err != nil {
+		return false, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`); err != nil {
+		return false, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if current > SchemaVersion {
+		return false, fmt.Errorf("database schema version %d is newer than supported version %d", current, SchemaVersion)
+	}
+	if current == SchemaVersion {
+		return true, tx.Commit()
+	}
+
+	v := current + 1
+	for _, stmt := range migrations[v-1] {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return false, fmt.Errorf("failed to apply schema version %d: %w", v, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)`, v, time.Now().UTC()); err != nil {
+		return false, fmt.Errorf("failed to apply schema version %d: %w", v, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to apply schema version %d: %w", v, err)
+	}
+	return v == SchemaVersion, nil
+}
+
+func (s *SQLSink) exec(ctx context.Context, query string, args ...any) error {
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// scored returns values when the record is scored and as many NULLs
+// otherwise.
+func scored(ok bool, n int, values func() []any) []any {
+	if ok {
+		return values()
+	}
+	return make([]any, n)
+}
+
+// optional converts a pointer to a nullable value.
+func optional[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// PutCycle upserts a cycle into whoop_cycles.
+func (s *SQLSink) PutCycle(ctx context.Context, c *whoop.Cycle) error {
+	args := []any{c.ID, c.UserID, c.CreatedAt, c.UpdatedAt, c.Start, optional(c.End), c.TimezoneOffset, string(c.ScoreState)}
+	args = append(args, scored(c.IsScored(), 4, func() []any {
+		return []any{c.Score.Strain, c.Score.Kilojoule, c.Score.AverageHeartRate, c.Score.MaxHeartRate}
+	})...)
+	return s.exec(ctx, cycleTable.upsert(), args...)
+}
+
+// PutSleep upserts a sleep into whoop_sleeps.
+func (s *SQLSink) PutSleep(ctx context.Context, sl *whoop.Sleep) error {
+	args := []any{sl.ID, sl.CycleID, sl.UserID, sl.CreatedAt, sl.UpdatedAt, sl.Start, sl.End, sl.TimezoneOffset, sl.Nap, string(sl.ScoreState)}
+	args = append(args, scored(sl.IsScored(), 4, func() []any {
+		sc := sl.Score
+		return []any{sc.RespiratoryRate, sc.SleepPerformancePercentage, sc.SleepConsistencyPercentage, sc.SleepEfficiencyPercentage}
+	})...)
+	args = append(args, scored(sl.IsScored() && sl.Score.StageSummary != nil, 8, func() []any {
+		st := sl.Score.StageSummary
+		return []any{
+			st.TotalInBedTimeMilli, st.TotalAwakeTimeMilli, st.TotalNoDataTimeMilli, st.TotalLightSleepTimeMilli,
+			st.TotalSlowWaveSleepTimeMilli, st.TotalRemSleepTimeMilli, st.SleepCycleCount, st.DisturbanceCount,
+		}
+	})...)
+	args = append(args, scored(sl.IsScored() && sl.Score.SleepNeeded != nil, 4, func() []any {
+		n := sl.Score.SleepNeeded
+		return []any{n.BaselineMilli, n.NeedFromSleepDebtMilli, n.NeedFromRecentStrainMilli, n.NeedFromRecentNapMilli}
+	})...)
+	return s.exec(ctx, sleepTable.upsert(), args...)
+}
+
+// PutWorkout upserts a workout into whoop_workouts.
+func (s *SQLSink) PutWorkout(ctx context.Context, w *whoop.Workout) error {
+	args := []any{w.ID, w.UserID, w.CreatedAt, w.UpdatedAt, w.Start, w.End, w.TimezoneOffset, w.SportID, w.SportName, string(w.ScoreState)}
+	args = append(args, scored(w.IsScored(), 8, func() []any {
+		sc := w.Score
+		return []any{
+			sc.Strain, sc.AverageHeartRate, sc.MaxHeartRate, sc.Kilojoule, sc.PercentRecorded,
+			optional(sc.DistanceMeter), optional(sc.AltitudeGainMeter), optional(sc.AltitudeChangeMeter),
+		}
+	})...)
+	args = append(args, scored(w.IsScored() && w.Score.ZoneDuration != nil, 6, func() []any {
+		z := w.Score.ZoneDuration
+		return []any{z.ZoneZeroMilli, z.ZoneOneMilli, z.ZoneTwoMilli, z.ZoneThreeMilli, z.ZoneFourMilli, z.ZoneFiveMilli}
+	})...)
+	return s.exec(ctx, workoutTable.upsert(), args...)
+}
+
+// PutRecovery upserts a recovery into whoop_recoveries, keyed by cycle ID.
+func (s *SQLSink) PutRecovery(ctx context.Context, r *whoop.Recovery) error {
+	args := []any{r.CycleID, r.SleepID, r.UserID, r.CreatedAt, r.UpdatedAt, string(r.ScoreState)}
+	args = append(args, scored(r.IsScored(), 6, func() []any {
+		sc := r.Score
+		return []any{sc.UserCalibrating, sc.RecoveryScore, sc.RestingHeartRate, sc.HrvRmssdMilli, sc.Spo2Percentage, sc.SkinTempCelsius}
+	})...)
+	return s.exec(ctx, recoveryTable.upsert(), args...)
+}
+
+// PutProfile upserts the user's profile into whoop_profiles.
+func (s *SQLSink) PutProfile(ctx context.Context, p *whoop.BasicProfile) error {
+	return s.exec(ctx, profileTable.upsert(), p.UserID, p.Email, p.FirstName, p.LastName)
+}
+
+// PutBodyMeasurement upserts the user's body measurements into
+// whoop_body_measurements.
+func (s *SQLSink) PutBodyMeasurement(ctx context.Context, userID int, m *whoop.BodyMeasurement) error {
+	return s.exec(ctx, bodyMeasurementTable.upsert(), userID, m.HeightMeter, m.WeightKilogram, m.MaxHeartRate)
+}
+
+// DeleteSleep deletes a sleep from whoop_sleeps.
+func (s *SQLSink) DeleteSleep(ctx context.Context, userID int, id string) error {
+	return s.exec(ctx, `DELETE FROM whoop_sleeps WHERE id = $1 AND user_id = $2`, id, userID)
+}
+
+// DeleteWorkout deletes a workout from whoop_workouts.
+func (s *SQLSink) DeleteWorkout(ctx context.Context, userID int, id string) error {
+	return s.exec(ctx, `DELETE FROM whoop_workouts WHERE id = $1 AND user_id = $2`, id, userID)
+}
+
+// DeleteRecovery deletes the recovery of the given sleep from
+// whoop_recoveries.
+func (s *SQLSink) DeleteRecovery(ctx context.Context, userID int, sleepID string) error {
+	return s.exec(ctx, `DELETE FROM whoop_recoveries WHERE sleep_id = $1 AND user_id = $2`, sleepID, userID)
+}
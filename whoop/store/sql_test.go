@@ -0,0 +1,406 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// fakeDB is an in-process database/sql driver that understands exactly the
+// statements SQLSink issues: CREATE TABLE and INDEX, the migrations
+// bookkeeping and its advisory lock, upserts with an optional updated_at
+// guard, and two-column deletes. It checks that every statement's
+// placeholders match its arguments.
+type fakeDB struct {
+	mu       sync.Mutex
+	lock     sync.Mutex                                    // the migration advisory lock
+	tables   map[string]map[string]map[string]driver.Value // table -> key -> column -> value
+	versions []int64
+	failOn   string        // Exec fails for statements containing it
+	slowRead time.Duration // delay after reading the schema version
+}
+
+func newFakeDB(t *testing.T) (*fakeDB, *sql.DB) {
+	t.Helper()
+	f := &fakeDB{tables: make(map[string]map[string]map[string]driver.Value)}
+	db := sql.OpenDB(fakeConnector{f})
+	t.Cleanup(func() { _ = db.Close() })
+	return f, db
+}
+
+func (f *fakeDB) row(table, key string) map[string]driver.Value {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tables[table][key]
+}
+
+var (
+	placeholderRE = regexp.MustCompile(`\$(\d+)`)
+	createRE      = regexp.MustCompile(`^CREATE TABLE (IF NOT EXISTS )?(\w+) `)
+	insertRE      = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES`)
+	deleteRE      = regexp.MustCompile(`^DELETE FROM (\w+) WHERE (\w+) = \$1 AND (\w+) = \$2$`)
+)
+
+func (f *fakeDB) exec(query string, args []driver.Value) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q := strings.Join(strings.Fields(query), " ")
+	if f.failOn != "" && strings.Contains(q, f.failOn) {
+		return errors.New("injected failure")
+	}
+	n := 0
+	for _, m := range placeholderRE.FindAllStringSubmatch(q, -1) {
+		i, _ := strconv.Atoi(m[1])
+		n = max(n, i)
+	}
+	if n != len(args) {
+		return fmt.Errorf("statement has %d placeholders but %d arguments: %s", n, len(args), q)
+	}
+
+	switch {
+	case strings.HasPrefix(q, "CREATE INDEX"):
+		return nil
+	case createRE.MatchString(q):
+		m := createRE.FindStringSubmatch(q)
+		if _, ok := f.tables[m[2]]; ok {
+			if m[1] != "" {
+				return nil
+			}
+			return fmt.Errorf("table %s already exists", m[2])
+		}
+		f.tables[m[2]] = make(map[string]map[string]driver.Value)
+		return nil
+	case strings.HasPrefix(q, "INSERT INTO schema_migrations"):
+		f.versions = append(f.versions, args[0].(int64))
+		return nil
+	case insertRE.MatchString(q):
+		m := insertRE.FindStringSubmatch(q)
+		rows, ok := f.tables[m[1]]
+		if !ok {
+			return fmt.Errorf("no table %s", m[1])
+		}
+		columns := strings.Split(m[2], ", ")
+		if len(columns) != len(args) {
+			return fmt.Errorf("%d columns but %d arguments", len(columns), len(args))
+		}
+		key := fmt.Sprint(args[0])
+		row := make(map[string]driver.Value, len(columns))
+		for i, c := range columns {
+			row[c] = args[i]
+		}
+		if old, ok := rows[key]; ok && strings.Contains(q, "WHERE "+m[1]+".updated_at <= excluded.updated_at") &&
+			old["updated_at"].(time.Time).After(row["updated_at"].(time.Time)) {
+			return nil
+		}
+		rows[key] = row
+		return nil
+	case deleteRE.MatchString(q):
+		m := deleteRE.FindStringSubmatch(q)
+		for key, row := range f.tables[m[1]] {
+			if fmt.Sprint(row[m[2]]) == fmt.Sprint(args[0]) && fmt.Sprint(row[m[3]]) == fmt.Sprint(args[1]) {
+				delete(f.tables[m[1]], key)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported statement: %s", q)
+}
+
+func (f *fakeDB) query(query string) (driver.Rows, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if query != `SELECT COALESCE(MAX(version), 0) FROM schema_migrations` {
+		return nil, fmt.Errorf("unsupported query: %s", query)
+	}
+	var v int64
+	for _, version := range f.versions {
+		v = max(v, version)
+	}
+	if f.slowRead > 0 {
+		// Widen the window between reading the version and applying the
+		// next one, where concurrent migrators race without the lock.
+		f.mu.Unlock()
+		time.Sleep(f.slowRead)
+		f.mu.Lock()
+	}
+	return &fakeRows{values: []driver.Value{v}}, nil
+}
+
+type fakeConnector struct{ db *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: c.db}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use the connector") }
+
+type fakeConn struct {
+	db     *fakeDB
+	locked bool // holds the advisory lock until the transaction ends
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{c}, nil }
+
+type fakeTx struct{ conn *fakeConn }
+
+func (tx fakeTx) Commit() error   { tx.conn.unlock(); return nil }
+func (tx fakeTx) Rollback() error { tx.conn.unlock(); return nil }
+
+func (c *fakeConn) unlock() {
+	if c.locked {
+		c.locked = false
+		c.db.lock.Unlock()
+	}
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.query == `SELECT pg_advisory_xact_lock($1)` {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected a lock key, got %v", args)
+		}
+		s.conn.db.lock.Lock()
+		s.conn.locked = true
+		return driver.RowsAffected(0), nil
+	}
+	return driver.RowsAffected(1), s.conn.db.exec(s.query, args)
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) { return s.conn.db.query(s.query) }
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func TestMigrations_MatchTables(t *testing.T) {
+	if len(migrations) != SchemaVersion {
+		t.Fatalf("SchemaVersion is %d but there are %d migrations", SchemaVersion, len(migrations))
+	}
+	ddl := strings.Join(migrations[0], "\n") + "\n"
+	for _, table := range []sqlTable{cycleTable, sleepTable, workoutTable, recoveryTable, profileTable, bodyMeasurementTable} {
+		start := strings.Index(ddl, "CREATE TABLE "+table.name+" (")
+		if start < 0 {
+			t.Fatalf("no CREATE TABLE for %s", table.name)
+		}
+		def := ddl[start : start+strings.Index(ddl[start:], ")\n")+1]
+		for _, c := range table.columns {
+			if !regexp.MustCompile(`\n\s*` + c + ` `).MatchString(def) {
+				t.Errorf("%s: column %s is not in the schema", table.name, c)
+			}
+		}
+		if got := strings.Count(def, ",") + 1; got != len(table.columns) {
+			t.Errorf("%s: schema has %d columns, upsert writes %d", table.name, got, len(table.columns))
+		}
+	}
+}
+
+func TestSQLSink_Migrate(t *testing.T) {
+	f, db := newFakeDB(t)
+	s := NewSQLSink(db)
+	ctx := context.Background()
+	if err := s.Migrate(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A second run must not reapply version 1, whose CREATE TABLEs would fail.
+	if err := s.Migrate(ctx); err != nil {
+		t.Fatalf("unexpected error migrating twice: %v", err)
+	}
+	if len(f.versions) != 1 || f.versions[0] != 1 {
+		t.Errorf("expected version 1 to be recorded once, got %v", f.versions)
+	}
+	if len(f.tables) != 7 {
+		t.Errorf("expected 7 tables, got %d", len(f.tables))
+	}
+
+	f.versions = append(f.versions, SchemaVersion+1)
+	if err := s.Migrate(ctx); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected an error for a newer schema, got %v", err)
+	}
+}
+
+func TestSQLSink_MigrateConcurrent(t *testing.T) {
+	f, db := newFakeDB(t)
+	f.slowRead = 10 * time.Millisecond
+	errs := make(chan error, 4)
+	for range cap(errs) {
+		go func() { errs <- NewSQLSink(db).Migrate(context.Background()) }()
+	}
+	for range cap(errs) {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if len(f.versions) != 1 {
+		t.Errorf("expected version 1 to be recorded once, got %v", f.versions)
+	}
+}
+
+func TestSQLSink_MigrateFailure(t *testing.T) {
+	f, db := newFakeDB(t)
+	f.failOn = "CREATE TABLE whoop_workouts"
+	if err := NewSQLSink(db).Migrate(context.Background()); err == nil || !strings.Contains(err.Error(), "version 1") {
+		t.Errorf("expected the failing version to be reported, got %v", err)
+	}
+	if len(f.versions) != 0 {
+		t.Errorf("expected no version to be recorded, got %v", f.versions)
+	}
+}
+
+func newMigratedSink(t *testing.T) (*fakeDB, *SQLSink) {
+	t.Helper()
+	f, db := newFakeDB(t)
+	s := NewSQLSink(db)
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return f, s
+}
+
+func TestSQLSink_PutSleep(t *testing.T) {
+	f, s := newMigratedSink(t)
+	ctx := context.Background()
+	sl := &whoop.Sleep{
+		ID: "s1", CycleID: 7, UserID: 1, UpdatedAt: day.Add(2 * time.Hour), Start: day, End: day.Add(8 * time.Hour),
+		ScoreState: whoop.ScoreStateScored,
+		Score: &whoop.SleepScore{
+			RespiratoryRate: 15.5,
+			StageSummary:    &whoop.StageSummary{TotalRemSleepTimeMilli: 6300000},
+		},
+	}
+	if err := s.PutSleep(ctx, sl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row := f.row("whoop_sleeps", "s1")
+	if row["respiratory_rate"] != 15.5 || row["total_rem_sleep_time_milli"] != int64(6300000) || row["score_state"] != "SCORED" {
+		t.Errorf("unexpected row %v", row)
+	}
+	if row["baseline_milli"] != nil {
+		t.Errorf("expected NULL sleep need without a SleepNeeded, got %v", row["baseline_milli"])
+	}
+
+	stale := *sl
+	stale.UpdatedAt = day.Add(time.Hour)
+	stale.ScoreState, stale.Score = whoop.ScoreStatePendingScore, nil
+	if err := s.PutSleep(ctx, &stale); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row := f.row("whoop_sleeps", "s1"); row["score_state"] != "SCORED" {
+		t.Errorf("expected a stale upsert to be ignored, got %v", row)
+	}
+
+	newer := stale
+	newer.UpdatedAt = day.Add(3 * time.Hour)
+	if err := s.PutSleep(ctx, &newer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row := f.row("whoop_sleeps", "s1"); row["score_state"] != "PENDING_SCORE" || row["respiratory_rate"] != nil {
+		t.Errorf("expected a newer unscored upsert to clear the score, got %v", row)
+	}
+}
+
+func TestSQLSink_PutOthers(t *testing.T) {
+	f, s := newMigratedSink(t)
+	ctx := context.Background()
+	distance := 5000.0
+	for _, put := range []func() error{
+		func() error {
+			return s.PutCycle(ctx, &whoop.Cycle{ID: 7, UserID: 1, Start: day, ScoreState: whoop.ScoreStateScored, Score: &whoop.Score{Strain: 12.4}})
+		},
+		func() error {
+			return s.PutWorkout(ctx, &whoop.Workout{ID: "w1", UserID: 1, SportID: 0, ScoreState: whoop.ScoreStateScored,
+				Score: &whoop.WorkoutScore{Strain: 11, DistanceMeter: &distance, ZoneDuration: &whoop.ZoneDurations{ZoneTwoMilli: 60000}}})
+		},
+		func() error {
+			return s.PutRecovery(ctx, &whoop.Recovery{CycleID: 7, SleepID: "s1", UserID: 1, ScoreState: whoop.ScoreStateScored,
+				Score: &whoop.RecoveryScore{RecoveryScore: 66, UserCalibrating: true}})
+		},
+		func() error { return s.PutProfile(ctx, &whoop.BasicProfile{UserID: 1, FirstName: "Alice"}) },
+		func() error { return s.PutBodyMeasurement(ctx, 1, &whoop.BodyMeasurement{MaxHeartRate: 190}) },
+	} {
+		if err := put(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if row := f.row("whoop_cycles", "7"); row["strain"] != 12.4 || row["end_time"] != nil {
+		t.Errorf("unexpected cycle row %v", row)
+	}
+	if row := f.row("whoop_workouts", "w1"); row["distance_meter"] != 5000.0 || row["altitude_gain_meter"] != nil || row["zone_two_milli"] != int64(60000) {
+		t.Errorf("unexpected workout row %v", row)
+	}
+	if row := f.row("whoop_recoveries", "7"); row["recovery_score"] != 66.0 || row["user_calibrating"] != true {
+		t.Errorf("unexpected recovery row %v", row)
+	}
+	if row := f.row("whoop_profiles", "1"); row["first_name"] != "Alice" {
+		t.Errorf("unexpected profile row %v", row)
+	}
+	if row := f.row("whoop_body_measurements", "1"); row["max_heart_rate"] != int64(190) {
+		t.Errorf("unexpected body measurement row %v", row)
+	}
+}
+
+func TestSQLSink_Delete(t *testing.T) {
+	f, s := newMigratedSink(t)
+	ctx := context.Background()
+	if err := s.PutWorkout(ctx, &whoop.Workout{ID: "w1", UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.PutRecovery(ctx, &whoop.Recovery{CycleID: 7, SleepID: "s1", UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A deletion for another user must not touch the record.
+	if err := s.DeleteWorkout(ctx, 2, "w1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.row("whoop_workouts", "w1") == nil {
+		t.Fatal("expected another user's deletion to be ignored")
+	}
+
+	for _, event := range []whoop.WebhookEvent{
+		{UserID: 1, ID: "w1", Type: "workout.deleted"},
+		{UserID: 1, ID: "s1", Type: "recovery.deleted"},
+		{UserID: 1, ID: "s1", Type: "sleep.deleted"},
+	} {
+		if err := ApplyWebhook(ctx, nil, s, &event); err != nil {
+			t.Fatalf("%s: unexpected error: %v", event.Type, err)
+		}
+	}
+	if f.row("whoop_workouts", "w1") != nil || f.row("whoop_recoveries", "7") != nil {
+		t.Error("expected the records to be deleted")
+	}
+}
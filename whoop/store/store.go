@@ -0,0 +1,496 @@
+package store
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+// Kind identifies the resource type of a stored record.
+type Kind string
+
+// Kinds of stored records.
+const (
+	KindCycle           Kind = "cycle"
+	KindSleep           Kind = "sleep"
+	KindWorkout         Kind = "workout"
+	KindRecovery        Kind = "recovery"
+	KindProfile         Kind = "profile"
+	KindBodyMeasurement Kind = "body_measurement"
+)
+
+// ErrNotFound is returned when a requested record is not in the store.
+var ErrNotFound = errors.New("record not found")
+
+// ErrClosed is returned by operations on a closed store.
+var ErrClosed = errors.New("store is closed")
+
+// Log operations.
+const (
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// logLine is one line of the log. Start, UpdatedAt and Ref duplicate parts of
+// Data so that replaying the log rebuilds the index without decoding records.
+type logLine struct {
+	Op        string          `json:"op"`
+	Kind      Kind            `json:"kind"`
+	UserID    int             `json:"user_id"`
+	ID        string          `json:"id,omitempty"`
+	Start     time.Time       `json:"start,omitzero"`
+	UpdatedAt time.Time       `json:"updated_at,omitzero"`
+	Ref       string          `json:"ref,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// bucket holds the records of one kind for one user.
+type bucket struct {
+	userID int
+	kind   Kind
+}
+
+// entry locates the latest line of a live record in the log.
+type entry struct {
+	start     time.Time
+	updatedAt time.Time
+	ref       string // a recovery's sleep ID, which its webhook events carry
+	offset    int64
+	size      int
+}
+
+// Stats describes the size of a store's log.
+type Stats struct {
+	Records int   // live records
+	Lines   int   // lines in the log, including superseded ones
+	Bytes   int64 // size of the log
+}
+
+// Store is a local, file-backed store of WHOOP records. It is safe for
+// concurrent use by multiple goroutines, but not by multiple processes.
+type Store struct {
+	mu    sync.RWMutex
+	path  string
+	f     *os.File
+	size  int64
+	lines int
+	index map[bucket]map[string]*entry
+}
+
+// Open opens the store at path, creating it if it does not exist, and replays
+// its log. A partial line at the end of the log, left by a crash mid-write,
+// is discarded.
+func Open(path string) (*Store, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{path: path, f: f, index: make(map[bucket]map[string]*entry)}
+	if err := s.replay(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// replay rebuilds the index from the log.
+func (s *Store) replay() error {
+	r := bufio.NewReader(s.f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return s.f.Truncate(s.size)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log: %w", err)
+		}
+
+		var l logLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return fmt.Errorf("invalid log line %d: %w", s.lines+1, err)
+		}
+		s.apply(&l, s.size, len(line))
+		s.size += int64(len(line))
+		s.lines++
+	}
+}
+
+// apply updates the index with a line written at offset.
+func (s *Store) apply(l *logLine, offset int64, size int) {
+	b := bucket{l.UserID, l.Kind}
+	switch l.Op {
+	case opPut:
+		if s.index[b] == nil {
+			s.index[b] = make(map[string]*entry)
+		}
+		s.index[b][l.ID] = &entry{start: l.Start, updatedAt: l.UpdatedAt, ref: l.Ref, offset: offset, size: size}
+	case opDelete:
+		delete(s.index[b], l.ID)
+	}
+}
+
+// appendLine writes l to the end of the log and applies it to the index.
+// s.mu must be held for writing.
+func (s *Store) appendLine(l *logLine) error {
+	if s.f == nil {
+		return ErrClosed
+	}
+	line, err := json.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", l.Kind, err)
+	}
+	line = append(line, '\n')
+	// A failed write leaves s.size unchanged, so the next write overwrites
+	// whatever part of the line made it to the file.
+	if _, err := s.f.WriteAt(line, s.size); err != nil {
+		return fmt.Errorf("failed to write log: %w", err)
+	}
+	s.apply(l, s.size, len(line))
+	s.size += int64(len(line))
+	s.lines++
+	return nil
+}
+
+// put upserts v unless the stored record was updated after updatedAt.
+func (s *Store) put(kind Kind, userID int, id string, start, updatedAt time.Time, ref string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", kind, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e := s.index[bucket{userID, kind}][id]; e != nil && e.updatedAt.After(updatedAt) {
+		return nil
+	}
+	return s.appendLine(&logLine{
+		Op: opPut, Kind: kind, UserID: userID, ID: id,
+		Start: start, UpdatedAt: updatedAt, Ref: ref, Data: data,
+	})
+}
+
+// PutCycle upserts a cycle.
+func (s *Store) PutCycle(c *whoop.Cycle) error {
+	return s.put(KindCycle, c.UserID, strconv.Itoa(c.ID), c.Start, c.UpdatedAt, "", c)
+}
+
+// PutSleep upserts a sleep.
+func (s *Store) PutSleep(sl *whoop.Sleep) error {
+	return s.put(KindSleep, sl.UserID, sl.ID, sl.Start, sl.UpdatedAt, "", sl)
+}
+
+// PutWorkout upserts a workout.
+func (s *Store) PutWorkout(w *whoop.Workout) error {
+	return s.put(KindWorkout, w.UserID, w.ID, w.Start, w.UpdatedAt, "", w)
+}
+
+// PutRecovery upserts a recovery, keyed by its cycle ID.
+func (s *Store) PutRecovery(r *whoop.Recovery) error {
+	return s.put(KindRecovery, r.UserID, strconv.Itoa(r.CycleID), r.CreatedAt, r.UpdatedAt, r.SleepID, r)
+}
+
+// PutProfile replaces the user's profile.
+func (s *Store) PutProfile(p *whoop.BasicProfile) error {
+	return s.put(KindProfile, p.UserID, "", time.Time{}, time.Time{}, "", p)
+}
+
+// PutBodyMeasurement replaces the user's body measurements. Body measurements
+// carry no user ID, so it is passed explicitly.
+func (s *Store) PutBodyMeasurement(userID int, m *whoop.BodyMeasurement) error {
+	return s.put(KindBodyMeasurement, userID, "", time.Time{}, time.Time{}, "", m)
+}
+
+// Delete removes a record. Deleting a record that is not stored is a no-op.
+// Recoveries are identified by cycle ID, and profiles and body measurements by
+// an empty id.
+func (s *Store) Delete(userID int, kind Kind, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return ErrClosed
+	}
+	if s.index[bucket{userID, kind}][id] == nil {
+		return nil
+	}
+	return s.appendLine(&logLine{Op: opDelete, Kind: kind, UserID: userID, ID: id})
+}
+
+// read decodes the stored record e into v. s.mu must be held.
+func (s *Store) read(e *entry, v any) error {
+	buf := make([]byte, e.size)
+	if _, err := s.f.ReadAt(buf, e.offset); err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+	var l logLine
+	if err := json.Unmarshal(buf, &l); err != nil {
+		return fmt.Errorf("invalid log line at offset %d: %w", e.offset, err)
+	}
+	if err := json.Unmarshal(l.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s record: %w", l.Kind, err)
+	}
+	return nil
+}
+
+// get returns the record of the given kind and id.
+func get[T any](s *Store, userID int, kind Kind, id string) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.f == nil {
+		return nil, ErrClosed
+	}
+	e := s.index[bucket{userID, kind}][id]
+	if e == nil {
+		return nil, ErrNotFound
+	}
+	var v T
+	if err := s.read(e, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// between returns the records of the given kind whose start falls within
+// [start, end), ordered by start. A zero start or end leaves that side of the
+// range unbounded.
+func between[T any](s *Store, userID int, kind Kind, start, end time.Time) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.f == nil {
+		return nil, ErrClosed
+	}
+
+	type match struct {
+		id string
+		e  *entry
+	}
+	var matches []match
+	for id, e := range s.index[bucket{userID, kind}] {
+		if !start.IsZero() && e.start.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !e.start.Before(end) {
+			continue
+		}
+		matches = append(matches, match{id, e})
+	}
+	slices.SortFunc(matches, func(a, b match) int {
+		if c := a.e.start.Compare(b.e.start); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	out := make([]T, len(matches))
+	for i, m := range matches {
+		if err := s.read(m.e, &out[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Cycle returns a stored cycle, or ErrNotFound.
+func (s *Store) Cycle(userID, id int) (*whoop.Cycle, error) {
+	return get[whoop.Cycle](s, userID, KindCycle, strconv.Itoa(id))
+}
+
+// Sleep returns a stored sleep, or ErrNotFound.
+func (s *Store) Sleep(userID int, id string) (*whoop.Sleep, error) {
+	return get[whoop.Sleep](s, userID, KindSleep, id)
+}
+
+// Workout returns a stored workout, or ErrNotFound.
+func (s *Store) Workout(userID int, id string) (*whoop.Workout, error) {
+	return get[whoop.Workout](s, userID, KindWorkout, id)
+}
+
+// Recovery returns the stored recovery for a cycle, or ErrNotFound.
+func (s *Store) Recovery(userID, cycleID int) (*whoop.Recovery, error) {
+	return get[whoop.Recovery](s, userID, KindRecovery, strconv.Itoa(cycleID))
+}
+
+// Profile returns the user's stored profile, or ErrNotFound.
+func (s *Store) Profile(userID int) (*whoop.BasicProfile, error) {
+	return get[whoop.BasicProfile](s, userID, KindProfile, "")
+}
+
+// BodyMeasurement returns the user's stored body measurements, or ErrNotFound.
+func (s *Store) BodyMeasurement(userID int) (*whoop.BodyMeasurement, error) {
+	return get[whoop.BodyMeasurement](s, userID, KindBodyMeasurement, "")
+}
+
+// Cycles returns the user's cycles starting within [start, end), in start
+// order. A zero start or end leaves that side of the range unbounded.
+func (s *Store) Cycles(userID int, start, end time.Time) ([]whoop.Cycle, error) {
+	return between[whoop.Cycle](s, userID, KindCycle, start, end)
+}
+
+// Sleeps returns the user's sleeps starting within [start, end), in start
+// order. A zero start or end leaves that side of the range unbounded.
+func (s *Store) Sleeps(userID int, start, end time.Time) ([]whoop.Sleep, error) {
+	return between[whoop.Sleep](s, userID, KindSleep, start, end)
+}
+
+// Workouts returns the user's workouts starting within [start, end), in start
+// order. A zero start or end leaves that side of the range unbounded.
+func (s *Store) Workouts(userID int, start, end time.Time) ([]whoop.Workout, error) {
+	return between[whoop.Workout](s, userID, KindWorkout, start, end)
+}
+
+// Recoveries returns the user's recoveries created within [start, end), in
+// creation order, since recoveries have no start time of their own. A zero
+// start or end leaves that side of the range unbounded.
+func (s *Store) Recoveries(userID int, start, end time.Time) ([]whoop.Recovery, error) {
+	return between[whoop.Recovery](s, userID, KindRecovery, start, end)
+}
+
+// Users returns the IDs of the users with stored records, in ascending order.
+func (s *Store) Users() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var users []int
+	for b, records := range s.index {
+		if len(records) > 0 && !slices.Contains(users, b.userID) {
+			users = append(users, b.userID)
+		}
+	}
+	slices.Sort(users)
+	return users
+}
+
+// ApplyWebhook applies a webhook event to the store. See the package-level
+// ApplyWebhook for how each event type is handled.
+func (s *Store) ApplyWebhook(ctx context.Context, client *whoop.Client, event *whoop.WebhookEvent) error {
+	return ApplyWebhook(ctx, client, storeSink{s}, event)
+}
+
+// deleteRecoveryBySleep removes the user's recovery for the given sleep.
+func (s *Store) deleteRecoveryBySleep(userID int, sleepID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return ErrClosed
+	}
+	for id, e := range s.index[bucket{userID, KindRecovery}] {
+		if e.ref == sleepID {
+			return s.appendLine(&logLine{Op: opDelete, Kind: KindRecovery, UserID: userID, ID: id})
+		}
+	}
+	return nil
+}
+
+// Stats reports the size of the store's log. Lines well above Records means
+// Compact would reclaim space.
+func (s *Store) Stats() Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st := Stats{Lines: s.lines, Bytes: s.size}
+	for _, records := range s.index {
+		st.Records += len(records)
+	}
+	return st
+}
+
+// Compact rewrites the log with only the latest line of each live record,
+// dropping superseded puts and deletions. The new log is written to a
+// temporary file and renamed over the old one, so a crash during Compact
+// leaves the old log intact.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return ErrClosed
+	}
+
+	tmp := s.path + ".compact"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp) }()
+
+	// Copy lines in log order, so the new log replays the same way.
+	var live []*entry
+	for _, records := range s.index {
+		for _, e := range records {
+			live = append(live, e)
+		}
+	}
+	slices.SortFunc(live, func(a, b *entry) int { return cmp.Compare(a.offset, b.offset) })
+
+	w := bufio.NewWriter(f)
+	var size int64
+	offsets := make([]int64, len(live))
+	for i, e := range live {
+		line := make([]byte, e.size)
+		if _, err := s.f.ReadAt(line, e.offset); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to read log: %w", err)
+		}
+		if _, err := w.Write(line); err != nil {
+			_ = f.Close()
+			return err
+		}
+		offsets[i] = size
+		size += int64(e.size)
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	_ = s.f.Close()
+	s.f = f
+	s.size = size
+	s.lines = len(live)
+	for i, e := range live {
+		e.offset = offsets[i]
+	}
+	return nil
+}
+
+// Sync commits the log to stable storage.
+func (s *Store) Sync() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.f == nil {
+		return ErrClosed
+	}
+	return s.f.Sync()
+}
+
+// Close closes the store's log. Operations on a closed store return
+// ErrClosed.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return ErrClosed
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
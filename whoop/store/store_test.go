@@ -0,0 +1,279 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arvarik/whoop-go/whoop"
+)
+
+var day = time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+
+func openTemp(t *testing.T) (*Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "whoop.log")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s, path
+}
+
+func sleepAt(id string, userID int, start time.Time, updated time.Time) *whoop.Sleep {
+	return &whoop.Sleep{ID: id, UserID: userID, Start: start, End: start.Add(8 * time.Hour), UpdatedAt: updated, ScoreState: whoop.ScoreStatePendingScore}
+}
+
+func TestStore_PutAndGet(t *testing.T) {
+	s, _ := openTemp(t)
+	if err := s.PutCycle(&whoop.Cycle{ID: 10, UserID: 1, Start: day, ScoreState: whoop.ScoreStateScored, Score: &whoop.Score{Strain: 12.4}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.PutProfile(&whoop.BasicProfile{UserID: 1, FirstName: "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.PutBodyMeasurement(1, &whoop.BodyMeasurement{MaxHeartRate: 190}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c, err := s.Cycle(1, 10)
+	if err != nil || c.Score == nil || c.Score.Strain != 12.4 {
+		t.Errorf("unexpected cycle %+v, err %v", c, err)
+	}
+	if p, err := s.Profile(1); err != nil || p.FirstName != "Alice" {
+		t.Errorf("unexpected profile %+v, err %v", p, err)
+	}
+	if m, err := s.BodyMeasurement(1); err != nil || m.MaxHeartRate != 190 {
+		t.Errorf("unexpected body measurement %+v, err %v", m, err)
+	}
+	if _, err := s.Cycle(2, 10); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for another user's cycle, got %v", err)
+	}
+}
+
+func TestStore_UpsertKeepsNewest(t *testing.T) {
+	s, _ := openTemp(t)
+	newer := sleepAt("s1", 1, day, day.Add(2*time.Hour))
+	newer.ScoreState = whoop.ScoreStateScored
+	if err := s.PutSleep(newer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.PutSleep(sleepAt("s1", 1, day, day.Add(time.Hour))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := s.Sleep(1, "s1")
+	if err != nil || got.ScoreState != whoop.ScoreStateScored {
+		t.Errorf("expected the stale upsert to be ignored, got %+v, err %v", got, err)
+	}
+	if st := s.Stats(); st.Records != 1 || st.Lines != 1 {
+		t.Errorf("unexpected stats %+v", st)
+	}
+}
+
+func TestStore_RangeQueries(t *testing.T) {
+	s, _ := openTemp(t)
+	for i, id := range []string{"c", "a", "b"} {
+		if err := s.PutSleep(sleepAt(id, 1, day.AddDate(0, 0, 2-i), day)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.PutSleep(sleepAt("other", 2, day, day)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	all, err := s.Sleeps(1, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != "b" || all[1].ID != "a" || all[2].ID != "c" {
+		t.Errorf("expected sleeps in start order, got %+v", all)
+	}
+
+	// The range is half-open: a sleep starting exactly at end is excluded.
+	some, err := s.Sleeps(1, day, day.AddDate(0, 0, 1))
+	if err != nil || len(some) != 1 || some[0].ID != "b" {
+		t.Errorf("unexpected range result %+v, err %v", some, err)
+	}
+
+	if err := s.PutRecovery(&whoop.Recovery{CycleID: 5, UserID: 1, CreatedAt: day.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rs, err := s.Recoveries(1, day, day.Add(2*time.Hour)); err != nil || len(rs) != 1 || rs[0].CycleID != 5 {
+		t.Errorf("expected recoveries ranged by CreatedAt, got %+v, err %v", rs, err)
+	}
+	if users := s.Users(); len(users) != 2 || users[0] != 1 || users[1] != 2 {
+		t.Errorf("unexpected users %v", users)
+	}
+}
+
+func TestStore_ReopenAndCompact(t *testing.T) {
+	s, path := openTemp(t)
+	for i := range 3 {
+		if err := s.PutWorkout(&whoop.Workout{ID: "w1", UserID: 1, Start: day, UpdatedAt: day.Add(time.Duration(i) * time.Hour), SportID: i}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.PutWorkout(&whoop.Workout{ID: "w2", UserID: 1, Start: day.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Delete(1, KindWorkout, "w2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	if st := s.Stats(); st.Records != 1 || st.Lines != 5 {
+		t.Errorf("unexpected stats after reopening %+v", st)
+	}
+	if w, err := s.Workout(1, "w1"); err != nil || w.SportID != 2 {
+		t.Errorf("expected the latest upsert, got %+v, err %v", w, err)
+	}
+	if _, err := s.Workout(1, "w2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the deleted workout to stay deleted, got %v", err)
+	}
+
+	if err := s.Compact(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st := s.Stats(); st.Records != 1 || st.Lines != 1 {
+		t.Errorf("unexpected stats after compacting %+v", st)
+	}
+	if err := s.PutWorkout(&whoop.Workout{ID: "w3", UserID: 1, Start: day}); err != nil {
+		t.Fatalf("unexpected error after compacting: %v", err)
+	}
+	if w, err := s.Workout(1, "w1"); err != nil || w.SportID != 2 {
+		t.Errorf("unexpected workout after compacting %+v, err %v", w, err)
+	}
+	if _, err := os.Stat(path + ".compact"); !os.IsNotExist(err) {
+		t.Errorf("expected the temporary file to be removed, got %v", err)
+	}
+}
+
+func TestOpen_PartialLine(t *testing.T) {
+	s, path := openTemp(t)
+	if err := s.PutSleep(sleepAt("s1", 1, day, day)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = s.Close()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"op":"put","kind":"sle`)
+	_ = f.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("expected a partial last line to be discarded, got %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	if err := s.PutSleep(sleepAt("s2", 1, day, day)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, err := s.Sleeps(1, time.Time{}, time.Time{}); err != nil || len(got) != 2 {
+		t.Errorf("unexpected sleeps %+v, err %v", got, err)
+	}
+}
+
+func TestOpen_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whoop.log")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("expected an error for a corrupt log line")
+	}
+}
+
+func TestStore_Closed(t *testing.T) {
+	s, _ := openTemp(t)
+	_ = s.Close()
+	if err := s.PutSleep(sleepAt("s1", 1, day, day)); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if _, err := s.Sleep(1, "s1"); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestStore_ApplyWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/activity/workout/w1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"w1","user_id":1,"start":"2026-02-24T10:00:00Z","sport_id":1,"score_state":"PENDING_SCORE"}`))
+	})
+	mux.HandleFunc("/activity/sleep/s1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"s1","cycle_id":7,"user_id":1,"start":"2026-02-24T00:00:00Z","score_state":"PENDING_SCORE"}`))
+	})
+	mux.HandleFunc("/cycle/7/recovery", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"cycle_id":7,"sleep_id":"s1","user_id":1,"score_state":"SCORED","score":{"recovery_score":66}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := whoop.NewClient(whoop.WithBaseURL(ts.URL), whoop.WithRateLimiting(false))
+	ctx := context.Background()
+
+	s, _ := openTemp(t)
+	for _, typ := range []string{"workout.updated", "sleep.updated"} {
+		id := "w1"
+		if typ == "sleep.updated" {
+			id = "s1"
+		}
+		if err := s.ApplyWebhook(ctx, client, &whoop.WebhookEvent{UserID: 1, ID: id, Type: typ}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", typ, err)
+		}
+	}
+	if err := s.ApplyWebhook(ctx, client, &whoop.WebhookEvent{UserID: 1, ID: "s1", Type: "recovery.updated"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, err := s.Recovery(1, 7); err != nil || r.Score == nil || r.Score.RecoveryScore != 66 {
+		t.Fatalf("expected the recovery to be fetched through its sleep, got %+v, err %v", r, err)
+	}
+
+	for _, event := range []whoop.WebhookEvent{
+		{UserID: 1, ID: "w1", Type: "workout.deleted"},
+		{UserID: 1, ID: "s1", Type: "sleep.deleted"},
+		{UserID: 1, ID: "s1", Type: "recovery.deleted"},
+		{UserID: 1, ID: "missing", Type: "sleep.deleted"},
+	} {
+		if err := s.ApplyWebhook(ctx, client, &event); err != nil {
+			t.Fatalf("%s: unexpected error: %v", event.Type, err)
+		}
+	}
+	if st := s.Stats(); st.Records != 0 {
+		t.Errorf("expected every record to be deleted, got %+v", st)
+	}
+
+	err := s.ApplyWebhook(ctx, client, &whoop.WebhookEvent{Type: "cycle.exploded"})
+	if !errors.Is(err, ErrUnsupportedEvent) {
+		t.Errorf("expected ErrUnsupportedEvent, got %v", err)
+	}
+}
+
+func TestStore_PreservesUnknownFields(t *testing.T) {
+	s, _ := openTemp(t)
+	var c whoop.Cycle
+	if err := json.Unmarshal([]byte(`{"id":1,"user_id":1,"score_state":"PENDING_SCORE","new_field":"x"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutCycle(&c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := s.Cycle(1, 1)
+	if err != nil || string(got.Extra["new_field"]) != `"x"` {
+		t.Errorf("expected unknown fields to round-trip, got %+v, err %v", got, err)
+	}
+}
@@ -0,0 +1,227 @@
+package whoop
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimezoneOffset is returned when a TimezoneOffset is not in the
+// ±HH:MM format used by the WHOOP API.
+var ErrInvalidTimezoneOffset = errors.New("invalid timezone offset")
+
+// ParseTimezoneOffset parses a WHOOP timezone offset such as "-08:00" or
+// "+05:30" into a fixed-offset time.Location. "Z" is accepted as UTC.
+//
+// WHOOP records the offset in effect when each record was captured, so
+// converting with the record's own offset stays correct across DST changes
+// and travel, unlike converting every record with a single IANA zone.
+func ParseTimezoneOffset(offset string) (*time.Location, error) {
+	if offset == "Z" {
+		return time.UTC, nil
+	}
+
+	if len(offset) != 6 || (offset[0] != '+' && offset[0] != '-') || offset[3] != ':' {
+		return nil, fmt.Errorf("%w %q: expected ±HH:MM", ErrInvalidTimezoneOffset, offset)
+	}
+
+	hours, okH := parseTwoDigits(offset[1:3])
+	minutes, okM := parseTwoDigits(offset[4:6])
+	if !okH || !okM || hours > 14 || minutes > 59 {
+		return nil, fmt.Errorf("%w %q: expected ±HH:MM", ErrInvalidTimezoneOffset, offset)
+	}
+
+	seconds := hours*3600 + minutes*60
+	if offset[0] == '-' {
+		seconds = -seconds
+	}
+	return time.FixedZone(offset, seconds), nil
+}
+
+// parseTwoDigits parses exactly two ASCII digits.
+func parseTwoDigits(s string) (int, bool) {
+	if len(s) != 2 || s[0] < '0' || s[0] > '9' || s[1] < '0' || s[1] > '9' {
+		return 0, false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), true
+}
+
+// Date is a calendar day without a time or location, used to group records
+// by the user's local day. Dates are comparable and can be used as map keys.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar day of t in t's own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a date in YYYY-MM-DD format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in YYYY-MM-DD format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// DaysSince returns the number of calendar days from o to d.
+func (d Date) DaysSince(o Date) int {
+	return int(d.In(time.UTC).Sub(o.In(time.UTC)).Hours() / 24)
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// Before reports whether d is before o.
+func (d Date) Before(o Date) bool {
+	return d.Compare(o) < 0
+}
+
+// After reports whether d is after o.
+func (d Date) After(o Date) bool {
+	return d.Compare(o) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or after o.
+func (d Date) Compare(o Date) int {
+	if c := cmp.Compare(d.Year, o.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(d.Month, o.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(d.Day, o.Day)
+}
+
+// Location returns the fixed-offset location the cycle was recorded in.
+func (c *Cycle) Location() (*time.Location, error) {
+	return ParseTimezoneOffset(c.TimezoneOffset)
+}
+
+// LocalStart returns Start in the cycle's recorded timezone.
+func (c *Cycle) LocalStart() (time.Time, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.Start.In(loc), nil
+}
+
+// LocalEnd returns End in the cycle's recorded timezone, or nil if the cycle
+// is still in progress.
+func (c *Cycle) LocalEnd() (*time.Time, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return nil, err
+	}
+	if c.End == nil {
+		return nil, nil
+	}
+	end := c.End.In(loc)
+	return &end, nil
+}
+
+// LocalDate returns the local calendar day the cycle belongs to. Cycles begin
+// when the user wakes up, so this is the local date of Start.
+func (c *Cycle) LocalDate() (Date, error) {
+	start, err := c.LocalStart()
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(start), nil
+}
+
+// Location returns the fixed-offset location the sleep was recorded in.
+func (s *Sleep) Location() (*time.Location, error) {
+	return ParseTimezoneOffset(s.TimezoneOffset)
+}
+
+// LocalStart returns Start in the sleep's recorded timezone.
+func (s *Sleep) LocalStart() (time.Time, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.Start.In(loc), nil
+}
+
+// LocalEnd returns End in the sleep's recorded timezone.
+func (s *Sleep) LocalEnd() (time.Time, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.End.In(loc), nil
+}
+
+// LocalDate returns the local calendar day the sleep belongs to. A night's
+// sleep is attributed to the day the user wakes up, so this is the local date
+// of End; naps follow the same rule.
+func (s *Sleep) LocalDate() (Date, error) {
+	end, err := s.LocalEnd()
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(end), nil
+}
+
+// Location returns the fixed-offset location the workout was recorded in.
+func (w *Workout) Location() (*time.Location, error) {
+	return ParseTimezoneOffset(w.TimezoneOffset)
+}
+
+// LocalStart returns Start in the workout's recorded timezone.
+func (w *Workout) LocalStart() (time.Time, error) {
+	loc, err := w.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return w.Start.In(loc), nil
+}
+
+// LocalEnd returns End in the workout's recorded timezone.
+func (w *Workout) LocalEnd() (time.Time, error) {
+	loc, err := w.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return w.End.In(loc), nil
+}
+
+// LocalDate returns the local calendar day the workout belongs to, which is
+// the local date of Start.
+func (w *Workout) LocalDate() (Date, error) {
+	start, err := w.LocalStart()
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(start), nil
+}
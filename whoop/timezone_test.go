@@ -0,0 +1,151 @@
+package whoop
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimezoneOffset(t *testing.T) {
+	tests := []struct {
+		offset  string
+		seconds int
+		wantErr bool
+	}{
+		{"-08:00", -8 * 3600, false},
+		{"+05:30", 5*3600 + 30*60, false},
+		{"+00:00", 0, false},
+		{"Z", 0, false},
+		{"+14:00", 14 * 3600, false},
+		{"", 0, true},
+		{"08:00", 0, true},
+		{"-8:00", 0, true},
+		{"-0800", 0, true},
+		{"+15:00", 0, true},
+		{"+05:60", 0, true},
+		{"+ab:00", 0, true},
+		{"America/New_York", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.offset, func(t *testing.T) {
+			loc, err := ParseTimezoneOffset(tt.offset)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTimezoneOffset) {
+					t.Errorf("expected ErrInvalidTimezoneOffset, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, got := time.Date(2026, 1, 1, 0, 0, 0, 0, loc).Zone()
+			if got != tt.seconds {
+				t.Errorf("expected offset %d seconds, got %d", tt.seconds, got)
+			}
+		})
+	}
+}
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2026-02-28")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.String() != "2026-02-28" {
+		t.Errorf("expected 2026-02-28, got %s", d)
+	}
+	if next := d.AddDays(1); next != (Date{2026, time.March, 1}) {
+		t.Errorf("expected 2026-03-01, got %s", next)
+	}
+	if prev := d.AddDays(-59); prev != (Date{2025, time.December, 31}) {
+		t.Errorf("expected 2025-12-31, got %s", prev)
+	}
+	if n := d.AddDays(10).DaysSince(d); n != 10 {
+		t.Errorf("expected 10 days, got %d", n)
+	}
+	if d.Weekday() != time.Saturday {
+		t.Errorf("expected Saturday, got %s", d.Weekday())
+	}
+	if !d.Before(d.AddDays(1)) || !d.After(d.AddDays(-1)) || d.Compare(d) != 0 {
+		t.Error("unexpected date ordering")
+	}
+	if !(Date{}).IsZero() || d.IsZero() {
+		t.Error("unexpected IsZero result")
+	}
+	if _, err := ParseDate("28/02/2026"); err == nil {
+		t.Error("expected error parsing malformed date")
+	}
+}
+
+func TestSleep_LocalDate_DSTTransition(t *testing.T) {
+	// The same UTC wake-up instant falls on different local days depending on
+	// whether the record was captured before or after the DST change.
+	end := time.Date(2026, 3, 9, 7, 30, 0, 0, time.UTC)
+
+	pdt := &Sleep{End: end, TimezoneOffset: "-07:00"}
+	pst := &Sleep{End: end, TimezoneOffset: "-08:00"}
+
+	if d, err := pdt.LocalDate(); err != nil || d != (Date{2026, time.March, 9}) {
+		t.Errorf("expected 2026-03-09 under -07:00, got %s (%v)", d, err)
+	}
+	if d, err := pst.LocalDate(); err != nil || d != (Date{2026, time.March, 8}) {
+		t.Errorf("expected 2026-03-08 under -08:00, got %s (%v)", d, err)
+	}
+
+	local, err := pdt.LocalEnd()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if local.Hour() != 0 || local.Minute() != 30 {
+		t.Errorf("expected local wake time 00:30, got %s", local.Format(time.Kitchen))
+	}
+}
+
+func TestWorkout_LocalTime_Travel(t *testing.T) {
+	start := time.Date(2026, 2, 24, 23, 30, 0, 0, time.UTC)
+	w := &Workout{Start: start, End: start.Add(time.Hour), TimezoneOffset: "+09:00"}
+
+	localStart, err := w.LocalStart()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if localStart.Hour() != 8 || localStart.Minute() != 30 {
+		t.Errorf("expected 08:30 local start, got %s", localStart.Format(time.Kitchen))
+	}
+	if !localStart.Equal(start) {
+		t.Error("expected local start to represent the same instant")
+	}
+	if d, _ := w.LocalDate(); d != (Date{2026, time.February, 25}) {
+		t.Errorf("expected 2026-02-25, got %s", d)
+	}
+	if end, _ := w.LocalEnd(); end.Hour() != 9 {
+		t.Errorf("expected 09:30 local end, got %s", end.Format(time.Kitchen))
+	}
+}
+
+func TestCycle_LocalTime(t *testing.T) {
+	start := time.Date(2026, 2, 24, 5, 0, 0, 0, time.UTC)
+	c := &Cycle{Start: start, TimezoneOffset: "-08:00"}
+
+	if d, err := c.LocalDate(); err != nil || d != (Date{2026, time.February, 23}) {
+		t.Errorf("expected 2026-02-23, got %s (%v)", d, err)
+	}
+
+	end, err := c.LocalEnd()
+	if err != nil || end != nil {
+		t.Errorf("expected nil end for active cycle, got %v (%v)", end, err)
+	}
+
+	e := start.Add(20 * time.Hour)
+	c.End = &e
+	end, err = c.LocalEnd()
+	if err != nil || end == nil || end.Hour() != 17 {
+		t.Errorf("expected 17:00 local end, got %v (%v)", end, err)
+	}
+
+	c.TimezoneOffset = "bogus"
+	if _, err := c.LocalStart(); !errors.Is(err, ErrInvalidTimezoneOffset) {
+		t.Errorf("expected ErrInvalidTimezoneOffset, got %v", err)
+	}
+}
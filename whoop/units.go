@@ -0,0 +1,292 @@
+package whoop
+
+import "time"
+
+const (
+	kilojoulesPerKilocalorie = 4.184
+	metersPerMile            = 1609.344
+	metersPerFoot            = 0.3048
+)
+
+// Energy is an amount of energy in kilojoules, the unit reported by the WHOOP API.
+type Energy float64
+
+// Kilojoules returns the energy in kilojoules.
+func (e Energy) Kilojoules() float64 {
+	return float64(e)
+}
+
+// Kilocalories returns the energy in kilocalories (dietary calories).
+func (e Energy) Kilocalories() float64 {
+	return float64(e) / kilojoulesPerKilocalorie
+}
+
+// Distance is a length in meters, the unit reported by the WHOOP API.
+type Distance float64
+
+// Meters returns the distance in meters.
+func (d Distance) Meters() float64 {
+	return float64(d)
+}
+
+// Kilometers returns the distance in kilometers.
+func (d Distance) Kilometers() float64 {
+	return float64(d) / 1000
+}
+
+// Miles returns the distance in statute miles.
+func (d Distance) Miles() float64 {
+	return float64(d) / metersPerMile
+}
+
+// Feet returns the distance in feet.
+func (d Distance) Feet() float64 {
+	return float64(d) / metersPerFoot
+}
+
+// Temperature is a temperature in degrees Celsius, the unit reported by the WHOOP API.
+type Temperature float64
+
+// Celsius returns the temperature in degrees Celsius.
+func (t Temperature) Celsius() float64 {
+	return float64(t)
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (t Temperature) Fahrenheit() float64 {
+	return float64(t)*9/5 + 32
+}
+
+// Pace is the time taken to cover one kilometer.
+type Pace time.Duration
+
+// PerKilometer returns the time taken to cover one kilometer.
+func (p Pace) PerKilometer() time.Duration {
+	return time.Duration(p)
+}
+
+// PerMile returns the time taken to cover one mile.
+func (p Pace) PerMile() time.Duration {
+	return time.Duration(float64(p) * metersPerMile / 1000)
+}
+
+// Speed is a velocity in meters per second.
+type Speed float64
+
+// MetersPerSecond returns the speed in meters per second.
+func (s Speed) MetersPerSecond() float64 {
+	return float64(s)
+}
+
+// KilometersPerHour returns the speed in kilometers per hour.
+func (s Speed) KilometersPerHour() float64 {
+	return float64(s) * 3.6
+}
+
+// MilesPerHour returns the speed in miles per hour.
+func (s Speed) MilesPerHour() float64 {
+	return float64(s) * 3600 / metersPerMile
+}
+
+// millis converts a WHOOP *_milli field to a time.Duration.
+func millis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Energy returns the energy expended during the cycle.
+func (s *Score) Energy() Energy {
+	return Energy(s.Kilojoule)
+}
+
+// Calories returns the energy expended during the cycle in kilocalories.
+func (s *Score) Calories() float64 {
+	return s.Energy().Kilocalories()
+}
+
+// Energy returns the energy expended during the workout.
+func (s *WorkoutScore) Energy() Energy {
+	return Energy(s.Kilojoule)
+}
+
+// Calories returns the energy expended during the workout in kilocalories.
+func (s *WorkoutScore) Calories() float64 {
+	return s.Energy().Kilocalories()
+}
+
+// Distance returns the distance covered during the workout. The boolean is
+// false when the API did not report a distance.
+func (s *WorkoutScore) Distance() (Distance, bool) {
+	if s.DistanceMeter == nil {
+		return 0, false
+	}
+	return Distance(*s.DistanceMeter), true
+}
+
+// AltitudeGain returns the total altitude gained during the workout. The
+// boolean is false when the API did not report it.
+func (s *WorkoutScore) AltitudeGain() (Distance, bool) {
+	if s.AltitudeGainMeter == nil {
+		return 0, false
+	}
+	return Distance(*s.AltitudeGainMeter), true
+}
+
+// AltitudeChange returns the net altitude change between the start and end of
+// the workout. The boolean is false when the API did not report it.
+func (s *WorkoutScore) AltitudeChange() (Distance, bool) {
+	if s.AltitudeChangeMeter == nil {
+		return 0, false
+	}
+	return Distance(*s.AltitudeChangeMeter), true
+}
+
+// Duration returns the elapsed time between Start and End.
+func (w *Workout) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// AverageSpeed returns the average speed over the workout's elapsed time. The
+// boolean is false when the workout has no score, no distance, or no duration.
+func (w *Workout) AverageSpeed() (Speed, bool) {
+	if w.Score == nil {
+		return 0, false
+	}
+	dist, ok := w.Score.Distance()
+	elapsed := w.Duration()
+	if !ok || dist <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+	return Speed(dist.Meters() / elapsed.Seconds()), true
+}
+
+// AveragePace returns the average time per kilometer over the workout's
+// elapsed time. The boolean is false when AverageSpeed is unavailable.
+func (w *Workout) AveragePace() (Pace, bool) {
+	speed, ok := w.AverageSpeed()
+	if !ok {
+		return 0, false
+	}
+	return Pace(time.Duration(1000 / speed.MetersPerSecond() * float64(time.Second))), true
+}
+
+// SkinTemp returns the skin temperature recorded during sleep.
+func (s *RecoveryScore) SkinTemp() Temperature {
+	return Temperature(s.SkinTempCelsius)
+}
+
+// SkinTempFahrenheit returns the skin temperature in degrees Fahrenheit.
+func (s *RecoveryScore) SkinTempFahrenheit() float64 {
+	return s.SkinTemp().Fahrenheit()
+}
+
+// Duration returns the elapsed time between Start and End.
+func (s *Sleep) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// TotalInBedTime returns TotalInBedTimeMilli as a time.Duration.
+func (s *StageSummary) TotalInBedTime() time.Duration {
+	return millis(s.TotalInBedTimeMilli)
+}
+
+// TotalAwakeTime returns TotalAwakeTimeMilli as a time.Duration.
+func (s *StageSummary) TotalAwakeTime() time.Duration {
+	return millis(s.TotalAwakeTimeMilli)
+}
+
+// TotalNoDataTime returns TotalNoDataTimeMilli as a time.Duration.
+func (s *StageSummary) TotalNoDataTime() time.Duration {
+	return millis(s.TotalNoDataTimeMilli)
+}
+
+// TotalLightSleepTime returns TotalLightSleepTimeMilli as a time.Duration.
+func (s *StageSummary) TotalLightSleepTime() time.Duration {
+	return millis(s.TotalLightSleepTimeMilli)
+}
+
+// TotalSlowWaveSleepTime returns TotalSlowWaveSleepTimeMilli as a time.Duration.
+func (s *StageSummary) TotalSlowWaveSleepTime() time.Duration {
+	return millis(s.TotalSlowWaveSleepTimeMilli)
+}
+
+// TotalRemSleepTime returns TotalRemSleepTimeMilli as a time.Duration.
+func (s *StageSummary) TotalRemSleepTime() time.Duration {
+	return millis(s.TotalRemSleepTimeMilli)
+}
+
+// TotalSleepTime returns the time actually asleep: light, slow wave and REM
+// sleep combined, excluding time awake and time without data.
+func (s *StageSummary) TotalSleepTime() time.Duration {
+	return s.TotalLightSleepTime() + s.TotalSlowWaveSleepTime() + s.TotalRemSleepTime()
+}
+
+// Baseline returns BaselineMilli as a time.Duration.
+func (n *SleepNeeded) Baseline() time.Duration {
+	return millis(n.BaselineMilli)
+}
+
+// NeedFromSleepDebt returns NeedFromSleepDebtMilli as a time.Duration.
+func (n *SleepNeeded) NeedFromSleepDebt() time.Duration {
+	return millis(n.NeedFromSleepDebtMilli)
+}
+
+// NeedFromRecentStrain returns NeedFromRecentStrainMilli as a time.Duration.
+func (n *SleepNeeded) NeedFromRecentStrain() time.Duration {
+	return millis(n.NeedFromRecentStrainMilli)
+}
+
+// NeedFromRecentNap returns NeedFromRecentNapMilli as a time.Duration. WHOOP
+// reports this component as a non-positive reduction of the sleep need.
+func (n *SleepNeeded) NeedFromRecentNap() time.Duration {
+	return millis(n.NeedFromRecentNapMilli)
+}
+
+// Total returns the overall sleep need: the sum of the baseline, sleep debt,
+// recent strain and recent nap components.
+func (n *SleepNeeded) Total() time.Duration {
+	return n.Baseline() + n.NeedFromSleepDebt() + n.NeedFromRecentStrain() + n.NeedFromRecentNap()
+}
+
+// ZoneZero returns ZoneZeroMilli as a time.Duration.
+func (z *ZoneDurations) ZoneZero() time.Duration {
+	return millis(z.ZoneZeroMilli)
+}
+
+// ZoneOne returns ZoneOneMilli as a time.Duration.
+func (z *ZoneDurations) ZoneOne() time.Duration {
+	return millis(z.ZoneOneMilli)
+}
+
+// ZoneTwo returns ZoneTwoMilli as a time.Duration.
+func (z *ZoneDurations) ZoneTwo() time.Duration {
+	return millis(z.ZoneTwoMilli)
+}
+
+// ZoneThree returns ZoneThreeMilli as a time.Duration.
+func (z *ZoneDurations) ZoneThree() time.Duration {
+	return millis(z.ZoneThreeMilli)
+}
+
+// ZoneFour returns ZoneFourMilli as a time.Duration.
+func (z *ZoneDurations) ZoneFour() time.Duration {
+	return millis(z.ZoneFourMilli)
+}
+
+// ZoneFive returns ZoneFiveMilli as a time.Duration.
+func (z *ZoneDurations) ZoneFive() time.Duration {
+	return millis(z.ZoneFiveMilli)
+}
+
+// Zones returns the time spent in each heart rate zone, indexed from zone zero to zone five.
+func (z *ZoneDurations) Zones() [6]time.Duration {
+	return [6]time.Duration{z.ZoneZero(), z.ZoneOne(), z.ZoneTwo(), z.ZoneThree(), z.ZoneFour(), z.ZoneFive()}
+}
+
+// Total returns the time spent across all heart rate zones.
+func (z *ZoneDurations) Total() time.Duration {
+	var total time.Duration
+	for _, d := range z.Zones() {
+		total += d
+	}
+	return total
+}
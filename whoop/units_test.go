@@ -0,0 +1,157 @@
+package whoop
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func approxEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestUnitConversions(t *testing.T) {
+	if got := Energy(4184).Kilocalories(); !approxEqual(got, 1000, 1e-9) {
+		t.Errorf("expected 1000 kcal, got %f", got)
+	}
+	if got := Distance(1609.344).Miles(); !approxEqual(got, 1, 1e-9) {
+		t.Errorf("expected 1 mile, got %f", got)
+	}
+	if got := Distance(5000).Kilometers(); got != 5 {
+		t.Errorf("expected 5 km, got %f", got)
+	}
+	if got := Distance(100).Feet(); !approxEqual(got, 328.084, 1e-3) {
+		t.Errorf("expected 328.084 ft, got %f", got)
+	}
+	if got := Temperature(33.5).Fahrenheit(); !approxEqual(got, 92.3, 1e-9) {
+		t.Errorf("expected 92.3°F, got %f", got)
+	}
+	if got := Speed(10).KilometersPerHour(); got != 36 {
+		t.Errorf("expected 36 km/h, got %f", got)
+	}
+	if got := Speed(1609.344 / 3600).MilesPerHour(); !approxEqual(got, 1, 1e-9) {
+		t.Errorf("expected 1 mph, got %f", got)
+	}
+	if got := Pace(5 * time.Minute).PerMile(); got != 8*time.Minute+2*time.Second+803200*time.Microsecond {
+		t.Errorf("expected 8m2.8032s per mile, got %s", got)
+	}
+}
+
+func TestWorkout_DerivedMetrics(t *testing.T) {
+	ts := newMockServer(t)
+	defer ts.Close()
+
+	client := newMockClient(ts)
+
+	workout, err := client.Workout.GetByID(context.Background(), "wkt-uuid-456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := workout.Duration(); got != time.Hour {
+		t.Errorf("expected 1h duration, got %s", got)
+	}
+	if got := workout.Score.Calories(); !approxEqual(got, 167.423, 1e-3) {
+		t.Errorf("expected ~167.423 kcal, got %f", got)
+	}
+	if dist, ok := workout.Score.Distance(); !ok || dist.Kilometers() != 5 {
+		t.Errorf("expected 5 km, got %v (%v)", dist, ok)
+	}
+	if gain, ok := workout.Score.AltitudeGain(); !ok || gain.Meters() != 100 {
+		t.Errorf("expected 100 m altitude gain, got %v (%v)", gain, ok)
+	}
+	if change, ok := workout.Score.AltitudeChange(); !ok || change.Meters() != 10 {
+		t.Errorf("expected 10 m altitude change, got %v (%v)", change, ok)
+	}
+	if pace, ok := workout.AveragePace(); !ok || pace.PerKilometer() != 12*time.Minute {
+		t.Errorf("expected 12 min/km pace, got %v (%v)", time.Duration(pace), ok)
+	}
+
+	zones := workout.Score.ZoneDuration
+	if zones.ZoneThree() != 4*time.Second || zones.Total() != 21*time.Second {
+		t.Errorf("unexpected zone durations: zone3=%s total=%s", zones.ZoneThree(), zones.Total())
+	}
+	if got := zones.Zones(); got[0] != time.Second || got[5] != 6*time.Second {
+		t.Errorf("unexpected zones array: %v", got)
+	}
+}
+
+func TestWorkout_AveragePace_Unavailable(t *testing.T) {
+	start := time.Date(2026, 2, 24, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		workout Workout
+	}{
+		{"no score", Workout{Start: start, End: start.Add(time.Hour)}},
+		{"no distance", Workout{Start: start, End: start.Add(time.Hour), Score: &WorkoutScore{}}},
+		{"no duration", Workout{Start: start, End: start, Score: &WorkoutScore{DistanceMeter: new(float64)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.workout.AveragePace(); ok {
+				t.Error("expected pace to be unavailable")
+			}
+		})
+	}
+}
+
+func TestSleep_DurationAccessors(t *testing.T) {
+	ts := newMockServer(t)
+	defer ts.Close()
+
+	client := newMockClient(ts)
+
+	sleep, err := client.Sleep.GetByID(context.Background(), "slp-uuid-789")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sleep.Duration() != 8*time.Hour {
+		t.Errorf("expected 8h sleep, got %s", sleep.Duration())
+	}
+
+	stages := sleep.Score.StageSummary
+	if stages.TotalInBedTime() != 8*time.Hour {
+		t.Errorf("expected 8h in bed, got %s", stages.TotalInBedTime())
+	}
+	if stages.TotalAwakeTime() != time.Hour || stages.TotalNoDataTime() != 0 {
+		t.Errorf("unexpected awake/no-data time: %s/%s", stages.TotalAwakeTime(), stages.TotalNoDataTime())
+	}
+	if stages.TotalLightSleepTime() != 3*time.Hour || stages.TotalSlowWaveSleepTime() != 2*time.Hour || stages.TotalRemSleepTime() != 2*time.Hour {
+		t.Error("unexpected stage durations")
+	}
+	if stages.TotalSleepTime() != 7*time.Hour {
+		t.Errorf("expected 7h asleep, got %s", stages.TotalSleepTime())
+	}
+
+	need := sleep.Score.SleepNeeded
+	if need.Baseline() != 8*time.Hour || need.NeedFromSleepDebt() != 30*time.Minute || need.NeedFromRecentStrain() != 15*time.Minute || need.NeedFromRecentNap() != 0 {
+		t.Error("unexpected sleep need components")
+	}
+	if need.Total() != 8*time.Hour+45*time.Minute {
+		t.Errorf("expected 8h45m need, got %s", need.Total())
+	}
+
+	withNap := SleepNeeded{BaselineMilli: 28800000, NeedFromRecentNapMilli: -1800000}
+	if withNap.Total() != 7*time.Hour+30*time.Minute {
+		t.Errorf("expected nap to reduce need to 7h30m, got %s", withNap.Total())
+	}
+}
+
+func TestScore_Energy(t *testing.T) {
+	cycleScore := &Score{Kilojoule: 2048.5}
+	if cycleScore.Energy().Kilojoules() != 2048.5 {
+		t.Errorf("expected 2048.5 kJ, got %f", cycleScore.Energy().Kilojoules())
+	}
+	if !approxEqual(cycleScore.Calories(), 489.6, 0.1) {
+		t.Errorf("expected ~489.6 kcal, got %f", cycleScore.Calories())
+	}
+
+	recovery := &RecoveryScore{SkinTempCelsius: 36.6}
+	if recovery.SkinTemp().Celsius() != 36.6 || !approxEqual(recovery.SkinTempFahrenheit(), 97.88, 1e-9) {
+		t.Errorf("unexpected skin temperature conversion: %f", recovery.SkinTempFahrenheit())
+	}
+}
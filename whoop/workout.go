@@ -2,8 +2,10 @@ package whoop
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 	"time"
 )
 
@@ -19,8 +21,16 @@ type Workout struct {
 	TimezoneOffset string        `json:"timezone_offset"`
 	SportID        int           `json:"sport_id"`
 	SportName      string        `json:"sport_name"`
-	ScoreState     string        `json:"score_state"`
+	ScoreState     ScoreState    `json:"score_state"`
 	Score          *WorkoutScore `json:"score,omitempty"`
+
+	// Extra holds the JSON members not mapped to a field of Workout.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IsScored reports whether the workout has been scored and its Score is populated.
+func (w *Workout) IsScored() bool {
+	return w.ScoreState.IsScored() && w.Score != nil
 }
 
 // WorkoutScore details the cardiovascular output of a given workout.
@@ -34,6 +44,9 @@ type WorkoutScore struct {
 	AltitudeGainMeter   *float64       `json:"altitude_gain_meter"`
 	AltitudeChangeMeter *float64       `json:"altitude_change_meter"`
 	ZoneDuration        *ZoneDurations `json:"zone_durations"`
+
+	// Extra holds the JSON members not mapped to a field of WorkoutScore.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ZoneDurations breaks down the duration spent in different heart rate zones.
@@ -44,8 +57,14 @@ type ZoneDurations struct {
 	ZoneThreeMilli int `json:"zone_three_milli"`
 	ZoneFourMilli  int `json:"zone_four_milli"`
 	ZoneFiveMilli  int `json:"zone_five_milli"`
+
+	// Extra holds the JSON members not mapped to a field of ZoneDurations.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// WorkoutPath is the API path of the workout collection.
+const WorkoutPath = "/activity/workout"
+
 // WorkoutService handles communication with the workout related methods.
 type WorkoutService struct {
 	client *Client
@@ -53,26 +72,68 @@ type WorkoutService struct {
 
 // GetByID fetches a single workout session by its UUID.
 func (s *WorkoutService) GetByID(ctx context.Context, id string) (*Workout, error) {
+	if err := s.client.requireScope(ScopeReadWorkout); err != nil {
+		return nil, err
+	}
 	var item Workout
-	if err := s.client.Get(ctx, fmt.Sprintf("/activity/workout/%s", url.PathEscape(id)), &item); err != nil {
+	if err := s.client.Get(ctx, fmt.Sprintf(WorkoutPath+"/%s", url.PathEscape(id)), &item); err != nil {
 		return nil, err
 	}
 
 	return &item, nil
 }
 
+// GetMany fetches multiple workout sessions by ID concurrently, bounded by the client's
+// batch concurrency. Per-ID failures, including 404s for unknown IDs, are
+// reported in the result's Errors map without failing the whole batch.
+func (s *WorkoutService) GetMany(ctx context.Context, ids []string) *BatchResult[string, Workout] {
+	return getMany(ctx, s.client.batchConcurrency, ids, s.GetByID)
+}
+
+// WaitForScore polls GetByID every pollInterval until the workout is scored or
+// unscorable, which is typically needed right after a webhook event arrives.
+// A non-positive pollInterval defaults to 5 seconds.
+func (s *WorkoutService) WaitForScore(ctx context.Context, id string, pollInterval time.Duration) (*Workout, error) {
+	return waitForScore(ctx, pollInterval,
+		func(ctx context.Context) (*Workout, error) { return s.GetByID(ctx, id) },
+		func(item *Workout) ScoreState { return item.ScoreState },
+	)
+}
+
 // List fetches a paginated collection of workout sessions.
 func (s *WorkoutService) List(ctx context.Context, opts *ListOptions) (*WorkoutPage, error) {
-	page, err := getPaginated[Workout](ctx, s.client, "/activity/workout", opts)
+	return s.list(ctx, opts, nil)
+}
+
+// ListFiltered fetches a paginated collection of workout sessions, keeping only
+// the workouts that match filter. Filtering happens on the client, so a page
+// may contain fewer records than opts.Limit, or none at all, while NextToken
+// still points at further results. NextPage applies the same filter.
+func (s *WorkoutService) ListFiltered(ctx context.Context, opts *ListOptions, filter WorkoutFilter) (*WorkoutPage, error) {
+	return s.list(ctx, opts, &filter)
+}
+
+// list fetches a page of workouts and applies the optional client-side filter.
+func (s *WorkoutService) list(ctx context.Context, opts *ListOptions, filter *WorkoutFilter) (*WorkoutPage, error) {
+	if err := s.client.requireScope(ScopeReadWorkout); err != nil {
+		return nil, err
+	}
+	page, err := getPaginated[Workout](ctx, s.client, WorkoutPath, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	records := page.Records
+	if filter != nil {
+		records = slices.DeleteFunc(records, func(w Workout) bool { return !filter.Match(&w) })
+	}
+
 	return &WorkoutPage{
-		Records:   page.Records,
+		Records:   records,
 		NextToken: page.NextToken,
 		service:   s,
 		opts:      opts,
+		filter:    filter,
 	}, nil
 }
 
@@ -83,6 +144,7 @@ type WorkoutPage struct {
 
 	service *WorkoutService
 	opts    *ListOptions
+	filter  *WorkoutFilter
 }
 
 // NextPage fetches the subsequent page of Workouts based on NextToken.
@@ -91,5 +153,5 @@ func (p *WorkoutPage) NextPage(ctx context.Context) (*WorkoutPage, error) {
 		return nil, ErrNoNextPage
 	}
 
-	return p.service.List(ctx, nextPageOpts(p.opts, p.NextToken))
+	return p.service.list(ctx, nextPageOpts(p.opts, p.NextToken), p.filter)
 }